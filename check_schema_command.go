@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+// RunCheckSchema verifies that the fields odootools depends on exist in Odoo.
+func RunCheckSchema(c *cli.Context) error {
+	client, err := newOdooClient(c)
+	if err != nil {
+		return err
+	}
+	version, err := odooVersion(c, client)
+	if err != nil {
+		return err
+	}
+	querier, err := connectTechnicalUser(c)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "Checking schema of Odoo %s\n", version)
+	err = odoo.VerifySchema(c.Context, querier, model.Schema(version))
+	schemaErr := &odoo.SchemaError{}
+	if errors.As(err, &schemaErr) {
+		for _, problem := range schemaErr.Problems {
+			fmt.Fprintln(c.App.Writer, problem.String())
+		}
+		return fmt.Errorf("found %d schema problems", len(schemaErr.Problems))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, "Odoo schema is compatible")
+	return nil
+}
+
+func newCheckSchemaCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "check-schema",
+		Usage:  "Verifies that the Odoo models and fields that odootools depends on exist",
+		Action: RunCheckSchema,
+		Flags: append([]cli.Flag{
+			newOdooURLFlag(),
+			newOdooDBFlag(),
+		}, newOdooTransportFlags()...),
+	}
+}
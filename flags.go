@@ -2,12 +2,13 @@ package main
 
 import (
 	"github.com/urfave/cli/v2"
+	"github.com/vshn/odootools/pkg/odoo"
 )
 
 func newOdooURLFlag() *cli.StringFlag {
 	return &cli.StringFlag{
 		Name:     "odoo-url",
-		Usage:    "Odoo Base URL",
+		Usage:    "Odoo Base URL, including the path prefix if Odoo is served under a sub path (e.g. 'https://odoo.example.com/erp')",
 		Required: true,
 		EnvVars:  []string{"ODOO_URL"},
 	}
@@ -22,6 +23,22 @@ func newOdooDBFlag() *cli.StringFlag {
 	}
 }
 
+func newOdooLoginFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    "odoo-login",
+		Usage:   "Odoo login of the technical user that runs non-interactive commands",
+		EnvVars: []string{"ODOO_LOGIN"},
+	}
+}
+
+func newOdooAPIKeyFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    "odoo-api-key",
+		Usage:   "Odoo API key of the technical user that runs non-interactive commands",
+		EnvVars: []string{"ODOO_API_KEY"},
+	}
+}
+
 func newLogLevelFlag() *cli.IntFlag {
 	return &cli.IntFlag{
 		Name:    "log-level",
@@ -72,3 +89,89 @@ func newDefaultTimezoneFlag() *cli.StringFlag {
 		Value:   "Europe/Zurich",
 	}
 }
+
+func newRulesFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    "rules",
+		Usage:   "The path to a YAML file with the business rules of the overtime calculation. The rules of VSHN apply if empty",
+		EnvVars: []string{"RULES_FILE"},
+	}
+}
+
+func newOdooTimeoutFlag() *cli.DurationFlag {
+	return &cli.DurationFlag{
+		Name:    "odoo-timeout",
+		Usage:   "The maximum duration of a request to Odoo",
+		EnvVars: []string{"ODOO_TIMEOUT"},
+		Value:   odoo.DefaultTimeout,
+	}
+}
+
+func newOdooCAFileFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    "odoo-ca-file",
+		Usage:   "The path to a PEM file with additional certificate authorities to trust when connecting to Odoo",
+		EnvVars: []string{"ODOO_CA_FILE"},
+	}
+}
+
+func newOdooInsecureSkipVerifyFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:    "odoo-insecure-skip-verify",
+		Usage:   "Don't verify the TLS certificate of Odoo. Only use this in test environments",
+		EnvVars: []string{"ODOO_INSECURE_SKIP_VERIFY"},
+	}
+}
+
+func newOdooProxyURLFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    "odoo-proxy-url",
+		Usage:   "The URL of an HTTP proxy to connect to Odoo. If empty, HTTP_PROXY, HTTPS_PROXY and NO_PROXY are honoured",
+		EnvVars: []string{"ODOO_PROXY_URL"},
+	}
+}
+
+func newOdooMaxIdleConnsFlag() *cli.IntFlag {
+	return &cli.IntFlag{
+		Name:    "odoo-max-idle-conns",
+		Usage:   "The maximum number of idle connections to Odoo kept for reuse (0 uses the Go defaults)",
+		EnvVars: []string{"ODOO_MAX_IDLE_CONNS"},
+	}
+}
+
+func newOdooMaxConnsFlag() *cli.IntFlag {
+	return &cli.IntFlag{
+		Name:    "odoo-max-conns",
+		Usage:   "The maximum number of concurrent connections to Odoo (0 is unlimited)",
+		EnvVars: []string{"ODOO_MAX_CONNS"},
+	}
+}
+
+// newOdooTransportFlags returns the flags that configure the connection to Odoo, see newOdooClient.
+func newOdooTransportFlags() []cli.Flag {
+	return []cli.Flag{
+		newOdooTimeoutFlag(),
+		newOdooCAFileFlag(),
+		newOdooInsecureSkipVerifyFlag(),
+		newOdooProxyURLFlag(),
+		newOdooMaxIdleConnsFlag(),
+		newOdooMaxConnsFlag(),
+		newOdooVersionFlag(),
+	}
+}
+
+func newOdooVersionFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    "odoo-version",
+		Usage:   "Version of the Odoo server, e.g. '8.0' or '16.0'. Detected on startup if empty",
+		EnvVars: []string{"ODOO_VERSION"},
+	}
+}
+
+func newCheckSchemaFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:    "check-schema",
+		Usage:   "Report the server as not ready on /readyz while the Odoo schema doesn't match the expectations. Requires --odoo-login and --odoo-api-key",
+		EnvVars: []string{"CHECK_SCHEMA"},
+	}
+}
@@ -25,9 +25,12 @@ func main() {
 		Version: versionInfo.String(),
 		Flags: []cli.Flag{
 			newLogLevelFlag(),
+			newOdooLoginFlag(),
+			newOdooAPIKeyFlag(),
 		},
 		Commands: []*cli.Command{
 			newWebCommand(),
+			newCheckSchemaCommand(),
 		},
 	}
 
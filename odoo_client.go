@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"github.com/vshn/odootools/pkg/metrics"
+	"github.com/vshn/odootools/pkg/odoo"
+)
+
+func newOdooClient(c *cli.Context) (*odoo.Client, error) {
+	return odoo.NewClient(c.String(newOdooURLFlag().Name), odoo.ClientOptions{
+		UseDebugLogger: c.Int(newLogLevelFlag().Name) >= 2,
+		TransportOptions: odoo.TransportOptions{
+			Timeout:            c.Duration(newOdooTimeoutFlag().Name),
+			CAFile:             c.String(newOdooCAFileFlag().Name),
+			InsecureSkipVerify: c.Bool(newOdooInsecureSkipVerifyFlag().Name),
+			ProxyURL:           c.String(newOdooProxyURLFlag().Name),
+			MaxIdleConns:       c.Int(newOdooMaxIdleConnsFlag().Name),
+			MaxConnsPerHost:    c.Int(newOdooMaxConnsFlag().Name),
+			WrapTransport:      metrics.NewOdooTransport,
+		},
+	})
+}
+
+// odooVersion returns the version given by flag, or detects it from the Odoo server.
+func odooVersion(c *cli.Context, client *odoo.Client) (odoo.ServerVersion, error) {
+	if version := c.String(newOdooVersionFlag().Name); version != "" {
+		return odoo.ParseServerVersion(version)
+	}
+	version, err := client.FetchVersion(c.Context)
+	if err != nil {
+		return odoo.ServerVersion{}, fmt.Errorf("cannot detect Odoo version, set --%s to skip detection: %w", newOdooVersionFlag().Name, err)
+	}
+	return version, nil
+}
+
+// connectTechnicalUser logs in to Odoo with the API key of a technical user.
+// It's meant for non-interactive commands that don't have a user session.
+func connectTechnicalUser(c *cli.Context) (odoo.QueryExecutor, error) {
+	login, apiKey := c.String(newOdooLoginFlag().Name), c.String(newOdooAPIKeyFlag().Name)
+	if login == "" || apiKey == "" {
+		return nil, fmt.Errorf("flags --%s and --%s are required", newOdooLoginFlag().Name, newOdooAPIKeyFlag().Name)
+	}
+	client, err := newOdooClient(c)
+	if err != nil {
+		return nil, err
+	}
+	return client.Connect(c.Context, odoo.LoginOptions{
+		DatabaseName: c.String(newOdooDBFlag().Name),
+		Username:     login,
+		APIKey:       apiKey,
+	})
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EchoMiddleware records the duration of each HTTP request.
+// Requests are labelled with the route pattern (e.g. "/report/:employee/:year") instead of the actual path to keep the number of series bounded.
+func EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(e echo.Context) error {
+			start := time.Now()
+			err := next(e)
+			status := e.Response().Status
+			httpErr := &echo.HTTPError{}
+			if err != nil && errors.As(err, &httpErr) {
+				// the error handler writes the response after the middlewares returned
+				status = httpErr.Code
+			} else if err != nil && !e.Response().Committed {
+				status = http.StatusInternalServerError
+			}
+			route := e.Path()
+			if route == "" {
+				route = "unknown"
+			}
+			httpRequestDuration.WithLabelValues(route, e.Request().Method, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
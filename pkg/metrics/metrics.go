@@ -0,0 +1,94 @@
+// Package metrics provides the Prometheus metrics of the web server and its calls to Odoo.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "odootools"
+
+// Registry contains all metrics of odootools, including the Go runtime and process metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by route, method and status code.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"route", "method", "code"})
+
+	odooRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "odoo",
+		Name:      "requests_total",
+		Help:      "Number of requests sent to Odoo by endpoint and model.",
+	}, []string{"endpoint", "model"})
+	odooRequestErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "odoo",
+		Name:      "request_errors_total",
+		Help:      "Number of requests to Odoo that failed by endpoint and model, including errors returned by Odoo.",
+	}, []string{"endpoint", "model"})
+	odooRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "odoo",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of requests sent to Odoo by endpoint and model.",
+		Buckets:   []float64{.025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"endpoint", "model"})
+
+	pipelineStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "step_duration_seconds",
+		Help:      "Duration of the steps of the report pipelines by pipeline and step.",
+		Buckets:   []float64{.001, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"pipeline", "step"})
+
+	loginsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		odooRequestsTotal,
+		odooRequestErrorsTotal,
+		odooRequestDuration,
+		pipelineStepDuration,
+		loginsTotal,
+	)
+}
+
+// Handler returns the HTTP handler that serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// LoginResult is the outcome of a login attempt.
+type LoginResult string
+
+const (
+	// LoginSuccess is a login with valid credentials.
+	LoginSuccess LoginResult = "success"
+	// LoginInvalidCredentials is a login rejected by Odoo due to a wrong login or password.
+	LoginInvalidCredentials LoginResult = "invalid_credentials"
+	// LoginError is a login that failed for other reasons, e.g. if Odoo isn't reachable.
+	LoginError LoginResult = "error"
+)
+
+// ObserveLogin counts a login attempt.
+func ObserveLogin(result LoginResult) {
+	loginsTotal.WithLabelValues(string(result)).Inc()
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// odooTransport records the count, duration and errors of requests to Odoo.
+type odooTransport struct {
+	next http.RoundTripper
+}
+
+// NewOdooTransport returns an http.RoundTripper that records metrics of each request to Odoo and then passes the request to next.
+// Requests are labelled with the URL path as endpoint and the queried model, if the model can be read from the JSON-RPC request.
+// A request counts as failed if it couldn't be sent, if Odoo responded with an HTTP status other than 200, or with a JSON-RPC error or XML-RPC fault.
+func NewOdooTransport(next http.RoundTripper) http.RoundTripper {
+	return &odooTransport{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *odooTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := r.URL.Path
+	model := modelOfRequest(r)
+	odooRequestsTotal.WithLabelValues(endpoint, model).Inc()
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(r)
+	if err != nil {
+		odooRequestDuration.WithLabelValues(endpoint, model).Observe(time.Since(start).Seconds())
+		odooRequestErrorsTotal.WithLabelValues(endpoint, model).Inc()
+		return nil, err
+	}
+	failed := res.StatusCode != http.StatusOK
+	if !failed {
+		// the body has to be read in order to find errors returned by Odoo
+		buf, readErr := io.ReadAll(res.Body)
+		res.Body.Close()
+		if readErr != nil {
+			odooRequestErrorsTotal.WithLabelValues(endpoint, model).Inc()
+			return nil, readErr
+		}
+		res.Body = io.NopCloser(bytes.NewReader(buf))
+		failed = isErrorResponse(buf)
+	}
+	odooRequestDuration.WithLabelValues(endpoint, model).Observe(time.Since(start).Seconds())
+	if failed {
+		odooRequestErrorsTotal.WithLabelValues(endpoint, model).Inc()
+	}
+	return res, nil
+}
+
+// modelOfRequest returns the model of a JSON-RPC request.
+// Queries of the web client contain the model in "params.model", while queries to the external API (`/jsonrpc`) contain it as fourth argument of the "object" service.
+// An empty string is returned if the model can't be determined, e.g. for logins and XML-RPC requests.
+func modelOfRequest(r *http.Request) string {
+	if r.Body == nil || r.GetBody == nil {
+		return ""
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	request := struct {
+		Params struct {
+			Model   string        `json:"model"`
+			Service string        `json:"service"`
+			Args    []interface{} `json:"args"`
+		} `json:"params"`
+	}{}
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		return ""
+	}
+	if request.Params.Model != "" {
+		return request.Params.Model
+	}
+	if request.Params.Service == "object" && len(request.Params.Args) > 3 {
+		if model, ok := request.Params.Args[3].(string); ok {
+			return model
+		}
+	}
+	return ""
+}
+
+// isErrorResponse returns true if the body is a JSON-RPC response with an error or an XML-RPC fault.
+func isErrorResponse(body []byte) bool {
+	if bytes.Contains(body, []byte("<fault>")) {
+		return true
+	}
+	response := struct {
+		Error json.RawMessage `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return false
+	}
+	return len(response.Error) > 0 && string(response.Error) != "null"
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOdooTransport(t *testing.T) {
+	tests := map[string]struct {
+		givenPath      string
+		givenRequest   string
+		givenStatus    int
+		givenResponse  string
+		expectedModel  string
+		expectedErrors float64
+	}{
+		"GivenSearchRead_ThenExpectModelFromParams": {
+			givenPath:     "/web/dataset/search_read",
+			givenRequest:  `{"id":"1","jsonrpc":"2.0","method":"call","params":{"model":"hr.attendance","domain":[]}}`,
+			givenStatus:   http.StatusOK,
+			givenResponse: `{"id":"1","jsonrpc":"2.0","result":{"length":0,"records":[]}}`,
+			expectedModel: "hr.attendance",
+		},
+		"GivenExternalAPICall_ThenExpectModelFromArgs": {
+			givenPath:     "/jsonrpc",
+			givenRequest:  `{"id":"1","jsonrpc":"2.0","method":"call","params":{"service":"object","method":"execute_kw","args":["db",2,"key","hr.contract","search_read",[]]}}`,
+			givenStatus:   http.StatusOK,
+			givenResponse: `{"id":"1","jsonrpc":"2.0","result":[]}`,
+			expectedModel: "hr.contract",
+		},
+		"GivenOdooError_ThenExpectErrorCount": {
+			givenPath:      "/web/dataset/call_kw/write",
+			givenRequest:   `{"id":"1","jsonrpc":"2.0","method":"call","params":{"model":"hr.payslip","method":"write"}}`,
+			givenStatus:    http.StatusOK,
+			givenResponse:  `{"id":"1","jsonrpc":"2.0","error":{"code":200,"message":"Odoo Server Error"}}`,
+			expectedModel:  "hr.payslip",
+			expectedErrors: 1,
+		},
+		"GivenBadGateway_ThenExpectErrorCount": {
+			givenPath:      "/web/session/authenticate",
+			givenRequest:   `{"id":"1","jsonrpc":"2.0","method":"call","params":{"db":"db","login":"user"}}`,
+			givenStatus:    http.StatusBadGateway,
+			expectedErrors: 1,
+		},
+		"GivenXMLRPCFault_ThenExpectErrorCount": {
+			givenPath:      "/xmlrpc/2/object",
+			givenRequest:   `<?xml version="1.0"?><methodCall><methodName>execute_kw</methodName></methodCall>`,
+			givenStatus:    http.StatusOK,
+			givenResponse:  `<?xml version="1.0"?><methodResponse><fault><value><struct></struct></value></fault></methodResponse>`,
+			expectedErrors: 1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.givenStatus)
+				_, _ = w.Write([]byte(tc.givenResponse))
+			}))
+			defer odooMock.Close()
+			requestsBefore := testutil.ToFloat64(odooRequestsTotal.WithLabelValues(tc.givenPath, tc.expectedModel))
+			errorsBefore := testutil.ToFloat64(odooRequestErrorsTotal.WithLabelValues(tc.givenPath, tc.expectedModel))
+
+			client := &http.Client{Transport: NewOdooTransport(http.DefaultTransport)}
+			res, err := client.Post(odooMock.URL+tc.givenPath, "application/json", strings.NewReader(tc.givenRequest))
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.givenStatus, res.StatusCode)
+			assert.Equal(t, requestsBefore+1, testutil.ToFloat64(odooRequestsTotal.WithLabelValues(tc.givenPath, tc.expectedModel)), "requests")
+			assert.Equal(t, errorsBefore+tc.expectedErrors, testutil.ToFloat64(odooRequestErrorsTotal.WithLabelValues(tc.givenPath, tc.expectedModel)), "errors")
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+)
+
+// InstrumentSteps records the duration of each given step, labelled with the name of the pipeline and the step.
+// Nested steps are recorded as a whole.
+// Step names end up as label values, so they shouldn't contain variable parts like employee names.
+func InstrumentSteps[T context.Context](pipelineName string, steps ...pipeline.Step[T]) []pipeline.Step[T] {
+	instrumented := make([]pipeline.Step[T], len(steps))
+	for i, step := range steps {
+		observer := pipelineStepDuration.WithLabelValues(pipelineName, step.Name)
+		action := step.Action
+		step.Action = func(ctx T) error {
+			start := time.Now()
+			defer func() {
+				observer.Observe(time.Since(start).Seconds())
+			}()
+			return action(ctx)
+		}
+		instrumented[i] = step
+	}
+	return instrumented
+}
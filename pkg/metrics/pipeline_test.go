@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentSteps(t *testing.T) {
+	p := pipeline.NewPipeline[context.Context]()
+	p.WithSteps(InstrumentSteps("test",
+		p.NewStep("succeed", func(_ context.Context) error { return nil }),
+		p.NewStep("fail", func(_ context.Context) error { return errors.New("failed") }),
+		p.NewStep("skipped", func(_ context.Context) error { return nil }),
+	)...)
+
+	err := p.RunWithContext(context.Background())
+	assert.EqualError(t, err, "step 'fail' failed: failed")
+	assert.Equal(t, uint64(1), sampleCount(t, "test", "succeed"))
+	assert.Equal(t, uint64(1), sampleCount(t, "test", "fail"))
+	assert.Equal(t, uint64(0), sampleCount(t, "test", "skipped"))
+}
+
+func sampleCount(t *testing.T, pipelineName, step string) uint64 {
+	m := &dto.Metric{}
+	require.NoError(t, pipelineStepDuration.WithLabelValues(pipelineName, step).(prometheus.Histogram).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
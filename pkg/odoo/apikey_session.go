@@ -0,0 +1,56 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// APIKeySession implements QueryExecutor using the external JSON-RPC API of Odoo (`/jsonrpc`), authenticated with an API key.
+// It doesn't depend on a web session, which makes it suitable for non-interactive use like cron jobs or technical users.
+type APIKeySession struct {
+	externalAPI
+}
+
+// LoginAPIKey authenticates the user with LoginOptions.APIKey against the external JSON-RPC API of Odoo.
+// It returns ErrInvalidCredentials if the login or API key were wrong.
+func (c *Client) LoginAPIKey(ctx context.Context, options LoginOptions) (*APIKeySession, error) {
+	if options.APIKey == "" {
+		return nil, fmt.Errorf("login: API key is required")
+	}
+	api, err := authenticateExternal(ctx, c.callJSONRPCService, c.retryPolicy, options.DatabaseName, options.Username, options.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	return &APIKeySession{externalAPI: api}, nil
+}
+
+// callJSONRPCService implements externalCall.
+func (c *Client) callJSONRPCService(ctx context.Context, service, method string, into interface{}, args ...interface{}) error {
+	body, err := NewJSONRPCRequest(map[string]interface{}{
+		"service": service,
+		"method":  method,
+		"args":    args,
+	}).Encode()
+	if err != nil {
+		return newEncodingRequestError(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint("/jsonrpc"), body)
+	if err != nil {
+		return newCreatingRequestError(err)
+	}
+	req.Header.Set("content-type", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return &networkError{err: err}
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return &HTTPStatusError{StatusCode: res.StatusCode, Status: res.Status}
+	}
+	if err := DecodeResult(res.Body, into); err != nil {
+		return newDecodingResultError(err)
+	}
+	return nil
+}
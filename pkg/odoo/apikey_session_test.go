@@ -0,0 +1,154 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+func TestAPIKeySession(t *testing.T) {
+	calls := 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/jsonrpc", r.URL.Path)
+		assert.Empty(t, r.Header.Get("cookie"))
+		req := struct {
+			Params struct {
+				Service string        `json:"service"`
+				Method  string        `json:"method"`
+				Args    []interface{} `json:"args"`
+			} `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("content-type", "application/json")
+		switch req.Params.Method {
+		case "authenticate":
+			assert.Equal(t, "common", req.Params.Service)
+			assert.Equal(t, []interface{}{"TestDB", "cron", "api-key", map[string]interface{}{}}, req.Params.Args)
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":9}`))
+		case "execute_kw":
+			assert.Equal(t, "object", req.Params.Service)
+			assert.Equal(t, []interface{}{
+				"TestDB", float64(9), "api-key", "hr.employee", "search_read",
+				[]interface{}{[]interface{}{[]interface{}{"id", "=", float64(2)}}},
+				map[string]interface{}{"fields": []interface{}{"name"}},
+			}, req.Params.Args)
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"2","result":[{"id":2,"name":"Jane Doe"}]}`))
+		default:
+			t.Errorf("unexpected method: %s", req.Params.Method)
+		}
+	}))
+	defer odooMock.Close()
+
+	client, err := NewClient(odooMock.URL, ClientOptions{})
+	require.NoError(t, err)
+	querier, err := client.Connect(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "cron", APIKey: "api-key"})
+	require.NoError(t, err)
+	require.IsType(t, &APIKeySession{}, querier)
+	assert.Equal(t, 9, querier.(*APIKeySession).UID)
+
+	result := struct {
+		Length  int `json:"length"`
+		Records []struct {
+			Name string `json:"name"`
+		} `json:"records"`
+	}{}
+	err = querier.SearchGenericModel(context.Background(), SearchReadModel{
+		Model:  "hr.employee",
+		Domain: domain.New(domain.Eq("id", 2)),
+		Fields: []string{"name"},
+	}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Length)
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, "Jane Doe", result.Records[0].Name)
+	assert.Equal(t, 2, calls)
+}
+
+func TestAPIKeySession_InvalidKey(t *testing.T) {
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":false}`))
+	}))
+	defer odooMock.Close()
+
+	client, err := NewClient(odooMock.URL, ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.LoginAPIKey(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "cron", APIKey: "wrong"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Nil(t, session)
+}
+
+func TestRedactor_APIKey(t *testing.T) {
+	tests := map[string]struct {
+		givenArgs []interface{}
+	}{
+		"GivenAuthenticate_ThenRedactKey": {
+			givenArgs: []interface{}{"TestDB", "cron", "api-key", map[string]interface{}{}},
+		},
+		"GivenExecuteKW_ThenRedactKey": {
+			givenArgs: []interface{}{"TestDB", 9, "api-key", "hr.employee", "search_read", []interface{}{}, map[string]interface{}{}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(NewJSONRPCRequest(map[string]interface{}{"service": "object", "method": "execute_kw", "args": tc.givenArgs}))
+			require.NoError(t, err)
+			redacted := string(newRedactor().redactRequest(b))
+			assert.NotContains(t, redacted, "api-key")
+			assert.Contains(t, redacted, `"[confidential]"`)
+			assert.Contains(t, redacted, `"TestDB"`)
+		})
+	}
+}
+
+func TestAPIKeySession_Retry(t *testing.T) {
+	numCalls, failures := 0, 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Params struct {
+				Method string `json:"method"`
+			} `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		result := "9"
+		if req.Params.Method == "execute_kw" {
+			numCalls++
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			result = "[]"
+		}
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":` + result + `}`))
+	}))
+	defer odooMock.Close()
+	client, err := NewClient(odooMock.URL, ClientOptions{RetryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}})
+	require.NoError(t, err)
+	session, err := client.LoginAPIKey(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "cron", APIKey: "api-key"})
+	require.NoError(t, err)
+
+	t.Run("GivenServiceUnavailable_WhenSearching_ThenRetry", func(t *testing.T) {
+		numCalls, failures = 0, 1
+		err := session.SearchGenericModel(context.Background(), SearchReadModel{Model: "hr.employee"}, &struct{}{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, numCalls)
+	})
+	t.Run("GivenServiceUnavailable_WhenDeleting_ThenReturnStatusError", func(t *testing.T) {
+		numCalls, failures = 0, 1
+		err := session.DeleteGenericModel(context.Background(), "hr.attendance", []int{1})
+		statusErr := &HTTPStatusError{}
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+		assert.Equal(t, 1, numCalls, "writes aren't retried")
+	})
+}
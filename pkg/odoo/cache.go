@@ -0,0 +1,249 @@
+package odoo
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheOptions configures a QueryCache.
+type CacheOptions struct {
+	// DefaultTTL is the time to live of cached search results of models that aren't listed in ModelTTL.
+	// Results aren't cached if the TTL is 0.
+	DefaultTTL time.Duration
+	// ModelTTL overrides DefaultTTL per model, e.g. "hr.contract".
+	ModelTTL map[string]time.Duration
+	// MaxBytes is the maximum total size of the cached results.
+	// The least recently used entries are evicted if the bound is exceeded.
+	MaxBytes int
+}
+
+// DefaultCacheOptions returns the cache settings for the web server.
+// Attendances and leaves are cached only briefly since they're usually edited in Odoo directly, while contracts and payslips of closed months almost never change.
+func DefaultCacheOptions() CacheOptions {
+	return CacheOptions{
+		DefaultTTL: 5 * time.Minute,
+		ModelTTL: map[string]time.Duration{
+			"hr.attendance":       time.Minute,
+			"hr.holidays":         time.Minute,
+			"hr.leave":            time.Minute,
+			"hr.leave.allocation": time.Minute,
+			"hr.contract":         30 * time.Minute,
+			"hr.payslip":          30 * time.Minute,
+			"resource.calendar":   time.Hour,
+			"hr.holidays.status":  time.Hour,
+			"hr.action.reason":    time.Hour,
+		},
+		MaxBytes: 64 * 1024 * 1024,
+	}
+}
+
+// QueryCache stores search results of QueryExecutor.SearchGenericModel in memory.
+// A QueryCache is safe for concurrent use and meant to be shared between requests, while the QueryExecutor returned by Wrap is usually created per request.
+type QueryCache struct {
+	options CacheOptions
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+	size  int
+	// generations counts the invalidations per model.
+	// Results of queries that were running while the model got invalidated aren't cached, since they might be stale already.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	key     string
+	model   string
+	value   json.RawMessage
+	expires time.Time
+}
+
+type bypassCacheKey struct{}
+
+// WithBypassCache returns a context that makes cached QueryExecutor fetch fresh results from Odoo.
+// The fresh results replace the cached ones.
+func WithBypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// IsCacheBypassed returns true if the context was created with WithBypassCache.
+func IsCacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
+
+// NewQueryCache returns a new, empty QueryCache.
+func NewQueryCache(options CacheOptions) *QueryCache {
+	return &QueryCache{
+		options:     options,
+		lru:         list.New(),
+		items:       map[string]*list.Element{},
+		generations: map[string]uint64{},
+	}
+}
+
+// Wrap returns a QueryExecutor that caches the search results of the given querier.
+// The scope is part of the cache key and should identify the user (e.g. the user ID), since Odoo returns different records depending on the user's access rights.
+// Creating, updating or deleting records invalidates all cached results of the affected model in all scopes.
+func (c *QueryCache) Wrap(querier QueryExecutor, scope string) QueryExecutor {
+	return &cachedQueryExecutor{QueryExecutor: querier, cache: c, scope: scope}
+}
+
+// Invalidate removes all cached results of the given model.
+func (c *QueryCache) Invalidate(model string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[model]++
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*cacheEntry).model == model {
+			c.remove(elem)
+		}
+		elem = next
+	}
+}
+
+// Size returns the total size in bytes of the cached results.
+func (c *QueryCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *QueryCache) ttl(model string) time.Duration {
+	if ttl, found := c.options.ModelTTL[model]; found {
+		return ttl
+	}
+	return c.options.DefaultTTL
+}
+
+func (c *QueryCache) get(key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, found := c.items[key]
+	if !found {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// generation returns the number of invalidations of the given model.
+func (c *QueryCache) generation(model string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[model]
+}
+
+// put stores the value unless the model has been invalidated since the given generation.
+func (c *QueryCache) put(key, model string, generation uint64, value json.RawMessage) {
+	ttl := c.ttl(model)
+	if ttl <= 0 || len(value) > c.options.MaxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[model] != generation {
+		return
+	}
+	if elem, found := c.items[key]; found {
+		c.remove(elem)
+	}
+	entry := &cacheEntry{key: key, model: model, value: value, expires: time.Now().Add(ttl)}
+	c.items[key] = c.lru.PushFront(entry)
+	c.size += len(value)
+	for c.size > c.options.MaxBytes {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove deletes the element. The mutex must be held by the caller.
+func (c *QueryCache) remove(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry)
+	c.lru.Remove(elem)
+	delete(c.items, entry.key)
+	c.size -= len(entry.value)
+}
+
+type cachedQueryExecutor struct {
+	QueryExecutor
+	cache *QueryCache
+	scope string
+}
+
+// SearchGenericModel implements QueryExecutor.
+func (q *cachedQueryExecutor) SearchGenericModel(ctx context.Context, model SearchReadModel, into interface{}) error {
+	if q.cache.ttl(model.Model) <= 0 {
+		return q.QueryExecutor.SearchGenericModel(ctx, model, into)
+	}
+	key, err := q.key(model)
+	if err != nil {
+		return err
+	}
+	if !IsCacheBypassed(ctx) {
+		if raw, found := q.cache.get(key); found {
+			return json.Unmarshal(raw, into)
+		}
+	}
+	generation := q.cache.generation(model.Model)
+	raw := json.RawMessage{}
+	if err := q.QueryExecutor.SearchGenericModel(ctx, model, &raw); err != nil {
+		return err
+	}
+	q.cache.put(key, model.Model, generation, raw)
+	return json.Unmarshal(raw, into)
+}
+
+// CreateGenericModel implements QueryExecutor.
+func (q *cachedQueryExecutor) CreateGenericModel(ctx context.Context, model string, data interface{}) (int, error) {
+	defer q.cache.Invalidate(model)
+	return q.QueryExecutor.CreateGenericModel(ctx, model, data)
+}
+
+// UpdateGenericModel implements QueryExecutor.
+func (q *cachedQueryExecutor) UpdateGenericModel(ctx context.Context, model string, id int, data interface{}) error {
+	defer q.cache.Invalidate(model)
+	return q.QueryExecutor.UpdateGenericModel(ctx, model, id, data)
+}
+
+// DeleteGenericModel implements QueryExecutor.
+func (q *cachedQueryExecutor) DeleteGenericModel(ctx context.Context, model string, ids []int) error {
+	defer q.cache.Invalidate(model)
+	return q.QueryExecutor.DeleteGenericModel(ctx, model, ids)
+}
+
+// ExecuteQuery implements QueryExecutor.
+// Queries are never cached, but writing queries invalidate the cached results of the model.
+func (q *cachedQueryExecutor) ExecuteQuery(ctx context.Context, path string, model interface{}, into interface{}) error {
+	if m, isWrite := model.(WriteModel); isWrite {
+		switch m.Method {
+		case MethodCreate, MethodWrite, MethodDelete:
+			defer q.cache.Invalidate(m.Model)
+		}
+	}
+	return q.QueryExecutor.ExecuteQuery(ctx, path, model, into)
+}
+
+func (q *cachedQueryExecutor) key(model SearchReadModel) (string, error) {
+	filter, err := json.Marshal(model.Domain)
+	if err != nil {
+		return "", fmt.Errorf("cannot create cache key: %w", err)
+	}
+	return strings.Join([]string{
+		q.scope,
+		model.Model,
+		string(filter),
+		strings.Join(model.Fields, ","),
+		fmt.Sprintf("%d:%d:%s:%t", model.Offset, model.Limit, model.Sort, model.CountTotal),
+	}, "|"), nil
+}
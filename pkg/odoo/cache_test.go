@@ -0,0 +1,147 @@
+package odoo
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+// writableQuerier is a pagedQuerier that also accepts updates.
+type writableQuerier struct {
+	pagedQuerier
+}
+
+func (q *writableQuerier) UpdateGenericModel(_ context.Context, _ string, _ int, _ interface{}) error {
+	return nil
+}
+
+func TestQueryCache_SearchGenericModel(t *testing.T) {
+	contracts := SearchReadModel{Model: "hr.contract", Domain: domain.New(domain.Eq("employee_id", 1))}
+	otherContracts := SearchReadModel{Model: "hr.contract", Domain: domain.New(domain.Eq("employee_id", 2))}
+	attendances := SearchReadModel{Model: "hr.attendance"}
+	tests := map[string]struct {
+		givenOptions     CacheOptions
+		givenScopes      []string
+		givenQueries     []SearchReadModel
+		givenContext     context.Context
+		expectedRequests int
+	}{
+		"GivenSameQuery_ThenFetchOnce": {
+			givenQueries:     []SearchReadModel{contracts, contracts},
+			expectedRequests: 1,
+		},
+		"GivenDifferentDomain_ThenFetchEach": {
+			givenQueries:     []SearchReadModel{contracts, otherContracts},
+			expectedRequests: 2,
+		},
+		"GivenDifferentScopes_ThenFetchEach": {
+			givenScopes:      []string{"1", "2"},
+			givenQueries:     []SearchReadModel{contracts, contracts},
+			expectedRequests: 2,
+		},
+		"GivenModelWithoutTTL_ThenFetchEach": {
+			givenQueries:     []SearchReadModel{attendances, attendances},
+			expectedRequests: 2,
+		},
+		"GivenBypassCache_ThenFetchEach": {
+			givenQueries:     []SearchReadModel{contracts, contracts},
+			givenContext:     WithBypassCache(context.Background()),
+			expectedRequests: 2,
+		},
+		"GivenMemoryBoundExceeded_ThenEvictOldest": {
+			givenOptions:     CacheOptions{DefaultTTL: time.Minute, MaxBytes: len(`{"records":[1,2,3]}`)},
+			givenQueries:     []SearchReadModel{contracts, otherContracts, contracts},
+			expectedRequests: 3,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := tc.givenOptions
+			if options.MaxBytes == 0 {
+				options = CacheOptions{DefaultTTL: time.Minute, ModelTTL: map[string]time.Duration{"hr.attendance": 0}, MaxBytes: 1024}
+			}
+			ctx := tc.givenContext
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			querier := &pagedQuerier{records: []int{1, 2, 3}}
+			cache := NewQueryCache(options)
+			for i, query := range tc.givenQueries {
+				scope := "1"
+				if len(tc.givenScopes) > i {
+					scope = tc.givenScopes[i]
+				}
+				result := List[int]{}
+				require.NoError(t, cache.Wrap(querier, scope).SearchGenericModel(ctx, query, &result))
+				assert.Equal(t, []int{1, 2, 3}, result.Items)
+			}
+			assert.Len(t, querier.requests, tc.expectedRequests)
+			assert.LessOrEqual(t, cache.Size(), options.MaxBytes)
+		})
+	}
+}
+
+func TestQueryCache_Expiry(t *testing.T) {
+	querier := &pagedQuerier{records: []int{1}}
+	cache := NewQueryCache(CacheOptions{DefaultTTL: time.Nanosecond, MaxBytes: 1024})
+	executor := cache.Wrap(querier, "1")
+	for i := 0; i < 2; i++ {
+		require.NoError(t, executor.SearchGenericModel(context.Background(), SearchReadModel{Model: "hr.payslip"}, &List[int]{}))
+		time.Sleep(time.Millisecond)
+	}
+	assert.Len(t, querier.requests, 2)
+}
+
+func TestQueryCache_InvalidateOnWrite(t *testing.T) {
+	querier := &writableQuerier{pagedQuerier{records: []int{1}}}
+	cache := NewQueryCache(CacheOptions{DefaultTTL: time.Minute, MaxBytes: 1024})
+	ctx := context.Background()
+	payslips := SearchReadModel{Model: "hr.payslip"}
+	contracts := SearchReadModel{Model: "hr.contract"}
+
+	hr := cache.Wrap(querier, "1")
+	employee := cache.Wrap(querier, "2")
+	require.NoError(t, employee.SearchGenericModel(ctx, payslips, &List[int]{}))
+	require.NoError(t, employee.SearchGenericModel(ctx, contracts, &List[int]{}))
+	require.NoError(t, hr.UpdateGenericModel(ctx, "hr.payslip", 1, map[string]interface{}{}))
+	require.NoError(t, employee.SearchGenericModel(ctx, payslips, &List[int]{}))
+	require.NoError(t, employee.SearchGenericModel(ctx, contracts, &List[int]{}))
+
+	models := make([]string, len(querier.requests))
+	for i, request := range querier.requests {
+		models[i] = request.Model
+	}
+	assert.Equal(t, "hr.payslip,hr.contract,hr.payslip", strings.Join(models, ","))
+}
+
+// invalidatingQuerier invalidates the cache while a search is running, like a concurrent write would.
+type invalidatingQuerier struct {
+	pagedQuerier
+	cache *QueryCache
+}
+
+func (q *invalidatingQuerier) SearchGenericModel(ctx context.Context, model SearchReadModel, into interface{}) error {
+	if len(q.requests) == 0 {
+		q.cache.Invalidate(model.Model)
+	}
+	return q.pagedQuerier.SearchGenericModel(ctx, model, into)
+}
+
+func TestQueryCache_InvalidateDuringSearch(t *testing.T) {
+	cache := NewQueryCache(CacheOptions{DefaultTTL: time.Minute, MaxBytes: 1024})
+	querier := &invalidatingQuerier{pagedQuerier: pagedQuerier{records: []int{1}}, cache: cache}
+	executor := cache.Wrap(querier, "1")
+	ctx := context.Background()
+	payslips := SearchReadModel{Model: "hr.payslip"}
+
+	require.NoError(t, executor.SearchGenericModel(ctx, payslips, &List[int]{}))
+	assert.Zero(t, cache.Size(), "stale result cached")
+	require.NoError(t, executor.SearchGenericModel(ctx, payslips, &List[int]{}))
+	require.NoError(t, executor.SearchGenericModel(ctx, payslips, &List[int]{}))
+	assert.Len(t, querier.requests, 2)
+}
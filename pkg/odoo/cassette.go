@@ -0,0 +1,264 @@
+package odoo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// CassetteMode defines whether the JSON-RPC traffic of a Client is recorded to or replayed from a cassette file.
+type CassetteMode string
+
+const (
+	// CassetteDisabled sends requests to Odoo without recording them.
+	CassetteDisabled CassetteMode = ""
+	// CassetteRecord sends requests to Odoo and writes each redacted request/response pair to the cassette file.
+	// An existing file is truncated.
+	CassetteRecord CassetteMode = "record"
+	// CassetteReplay doesn't send any requests to Odoo, responses are served from the cassette file instead.
+	CassetteReplay CassetteMode = "replay"
+)
+
+// cassetteInteraction is a single recorded request/response pair.
+// A cassette file contains one JSON-encoded interaction per line.
+type cassetteInteraction struct {
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	StatusCode int             `json:"status"`
+	Response   json.RawMessage `json:"response"`
+}
+
+// cassetteTransport records or replays JSON-RPC traffic.
+type cassetteTransport struct {
+	redactor
+	mode CassetteMode
+	path string
+	next http.RoundTripper
+
+	mu sync.Mutex
+	// interactions contains the recorded interactions that haven't been replayed yet, grouped by matchKey.
+	interactions map[string][]cassetteInteraction
+	// replayed contains the last replayed interaction by matchKey.
+	replayed map[string]cassetteInteraction
+}
+
+func (c *Client) useCassette(mode CassetteMode, path string) error {
+	switch mode {
+	case CassetteDisabled:
+		return nil
+	case CassetteRecord, CassetteReplay:
+	default:
+		return fmt.Errorf("unknown cassette mode: %q", mode)
+	}
+	if path == "" {
+		return fmt.Errorf("cassette mode %q requires a cassette file", mode)
+	}
+	next := c.http.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &cassetteTransport{
+		redactor:     newRedactor(),
+		mode:         mode,
+		path:         path,
+		next:         next,
+		interactions: map[string][]cassetteInteraction{},
+		replayed:     map[string]cassetteInteraction{},
+	}
+	if mode == CassetteRecord {
+		if err := os.WriteFile(path, []byte{}, 0o644); err != nil {
+			return fmt.Errorf("cannot create cassette: %w", err)
+		}
+	} else if err := t.load(); err != nil {
+		return err
+	}
+	c.http.Transport = t
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cassetteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(r)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: cannot read request body: %w", err)
+	}
+	interaction := cassetteInteraction{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Params: normalizeParams(t.redactRequest(reqBody)),
+	}
+	if t.mode == CassetteReplay {
+		return t.replay(r, interaction)
+	}
+	return t.record(r, interaction)
+}
+
+// readRequestBody returns the body of the request without consuming it.
+// If the request cannot provide a copy of its body, the body is buffered and replaced.
+func readRequestBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	if r.GetBody == nil {
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(buf))
+		return buf, nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+func (t *cassetteTransport) record(r *http.Request, interaction cassetteInteraction) (*http.Response, error) {
+	res, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: cannot read response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(buf))
+
+	interaction.StatusCode = res.StatusCode
+	interaction.Response = compactJSON(t.redactResponse(buf))
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: cannot encode interaction: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	file, err := os.OpenFile(t.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	return res, nil
+}
+
+// replay returns the recorded responses of matching requests in the order they were recorded.
+// If a request is sent more often than it was recorded, the last matching response is repeated.
+func (t *cassetteTransport) replay(r *http.Request, interaction cassetteInteraction) (*http.Response, error) {
+	key := interaction.matchKey()
+
+	t.mu.Lock()
+	recorded, found := t.replayed[key]
+	if queue := t.interactions[key]; len(queue) > 0 {
+		recorded, found = queue[0], true
+		t.interactions[key] = queue[1:]
+		t.replayed[key] = recorded
+	}
+	t.mu.Unlock()
+
+	if !found {
+		return nil, fmt.Errorf("cassette: no recorded interaction for %s %s with params %s", interaction.Method, interaction.Path, interaction.Params)
+	}
+	body := recorded.responseBody()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}, nil
+}
+
+func (t *cassetteTransport) load() error {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return fmt.Errorf("cannot open cassette: %w", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		interaction := cassetteInteraction{}
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return fmt.Errorf("cannot decode cassette %s line %d: %w", t.path, line, err)
+		}
+		// the cassette may have been edited by hand
+		interaction.Params = canonicalJSON(interaction.Params)
+		key := interaction.matchKey()
+		t.interactions[key] = append(t.interactions[key], interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("cannot read cassette: %w", err)
+	}
+	return nil
+}
+
+// responseBody returns the raw response body.
+func (i cassetteInteraction) responseBody() []byte {
+	var text string
+	if err := json.Unmarshal(i.Response, &text); err == nil {
+		return []byte(text)
+	}
+	return i.Response
+}
+
+func (i cassetteInteraction) matchKey() string {
+	return i.Method + " " + i.Path + " " + string(i.Params)
+}
+
+// normalizeParams returns the "params" of a JSON-RPC request body in a canonical form with sorted object keys.
+// The random request ID is thereby ignored.
+// Bodies that aren't JSON are encoded as JSON string.
+func normalizeParams(body []byte) json.RawMessage {
+	request := struct {
+		Params json.RawMessage `json:"params"`
+	}{}
+	if err := json.Unmarshal(body, &request); err == nil && request.Params != nil {
+		return canonicalJSON(request.Params)
+	}
+	return canonicalJSON(body)
+}
+
+// canonicalJSON re-encodes the given JSON with sorted object keys and without insignificant whitespace.
+func canonicalJSON(raw json.RawMessage) json.RawMessage {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		encoded, _ := json.Marshal(string(raw))
+		return encoded
+	}
+	normalized, _ := json.Marshal(decoded)
+	return normalized
+}
+
+// compactJSON returns the body as compact JSON.
+// Bodies that aren't JSON (e.g. error pages of a reverse proxy) are encoded as JSON string.
+func compactJSON(body []byte) json.RawMessage {
+	buf := new(bytes.Buffer)
+	if err := json.Compact(buf, body); err != nil {
+		encoded, _ := json.Marshal(string(body))
+		return encoded
+	}
+	return buf.Bytes()
+}
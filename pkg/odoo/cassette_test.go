@@ -0,0 +1,130 @@
+package odoo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+func TestClient_Cassette_RecordAndReplay(t *testing.T) {
+	numRequests := 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		w.Header().Set("content-type", "application/json")
+		switch r.RequestURI {
+		case "/web/session/authenticate":
+			_, _ = w.Write([]byte(`{"id":"1","jsonrpc":"2.0","result":{"session_id":"secret-session","uid":7}}`))
+		case "/web/dataset/search_read":
+			_, _ = w.Write([]byte(`{"id":"2","jsonrpc":"2.0","result":{"length":1,"records":[{"id":2,"name":"Jane Doe"}]}}`))
+		default:
+			t.Errorf("unexpected request: %s", r.RequestURI)
+		}
+	}))
+	cassette := filepath.Join(t.TempDir(), "cassette.jsonl")
+	query := SearchReadModel{Model: "hr.employee", Domain: domain.New(domain.Eq("id", 2)), Fields: []string{"name"}}
+
+	// Record
+	recorder, err := NewClient(odooMock.URL, ClientOptions{CassetteMode: CassetteRecord, CassettePath: cassette})
+	require.NoError(t, err)
+	recorded := runCassetteQueries(t, recorder, query)
+	odooMock.Close()
+	assert.Equal(t, 2, numRequests)
+
+	raw, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret-password")
+	assert.NotContains(t, string(raw), "secret-session")
+
+	// Replay
+	player, err := NewClient(odooMock.URL, ClientOptions{CassetteMode: CassetteReplay, CassettePath: cassette})
+	require.NoError(t, err)
+	replayed := runCassetteQueries(t, player, query)
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, 2, numRequests, "replay should not send requests")
+
+	// Unknown request
+	session := RestoreSession(player, "session", 7)
+	query.Domain = domain.New(domain.Eq("id", 3))
+	err = session.SearchGenericModel(context.Background(), query, &struct{}{})
+	assert.ErrorContains(t, err, "cassette: no recorded interaction for POST /web/dataset/search_read")
+}
+
+func runCassetteQueries(t *testing.T, client *Client, query SearchReadModel) interface{} {
+	session, err := client.Login(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret-password"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, session.UID)
+	result := map[string]interface{}{}
+	require.NoError(t, session.SearchGenericModel(context.Background(), query, &result))
+	return result
+}
+
+func TestNewClient_Cassette_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions  ClientOptions
+		expectedError string
+	}{
+		"GivenUnknownMode_ThenReturnError": {
+			givenOptions:  ClientOptions{CassetteMode: "rewind", CassettePath: "cassette.jsonl"},
+			expectedError: `unknown cassette mode: "rewind"`,
+		},
+		"GivenNoPath_ThenReturnError": {
+			givenOptions:  ClientOptions{CassetteMode: CassetteReplay},
+			expectedError: `cassette mode "replay" requires a cassette file`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient("http://localhost:8069", tc.givenOptions)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestReadRequestBody(t *testing.T) {
+	t.Run("GivenRequestWithoutGetBody_ThenBufferBody", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/web/dataset/search_read", io.NopCloser(strings.NewReader(`{"id":1}`)))
+		req.GetBody = nil
+		body, err := readRequestBody(req)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, string(body))
+		remaining, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, string(remaining), "body should still be readable")
+	})
+	t.Run("GivenRequestWithGetBody_ThenKeepBody", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/web/dataset/search_read", strings.NewReader(`{"id":1}`))
+		require.NoError(t, err)
+		body, err := readRequestBody(req)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, string(body))
+		remaining, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, string(remaining))
+	})
+	t.Run("GivenNoBody_ThenReturnNil", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+		body, err := readRequestBody(req)
+		require.NoError(t, err)
+		assert.Nil(t, body)
+	})
+}
+
+func TestNewClient_Cassette_WithDebugLogger(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.jsonl")
+	client, err := NewClient("http://odoo.example.com", ClientOptions{CassetteMode: CassetteRecord, CassettePath: cassette, UseDebugLogger: true})
+	require.NoError(t, err)
+
+	debug, isDebug := client.http.Transport.(*debugTransport)
+	require.True(t, isDebug, "debug logger should wrap the cassette to log replayed traffic")
+	assert.IsType(t, &cassetteTransport{}, debug.next)
+}
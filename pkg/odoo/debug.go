@@ -11,22 +11,49 @@ import (
 )
 
 type debugTransport struct {
-	pwRe   *regexp.Regexp
-	sessRe *regexp.Regexp
+	redactor
+	next http.RoundTripper
+}
+
+// redactor replaces credentials in raw JSON-RPC bodies with a placeholder.
+type redactor struct {
+	pwRe    *regexp.Regexp
+	sessRe  *regexp.Regexp
+	xmlPwRe *regexp.Regexp
+	keyRe   *regexp.Regexp
 }
 
 const confidentialPlaceholder = `$1[confidential]$2`
 
-func newDebugTransport() *debugTransport {
-	return &debugTransport{
+func newDebugTransport(next http.RoundTripper) *debugTransport {
+	return &debugTransport{redactor: newRedactor(), next: next}
+}
+
+func newRedactor() redactor {
+	return redactor{
 		pwRe:   regexp.MustCompile(`("password":\s?").+("[,}])`),
 		sessRe: regexp.MustCompile(`("session_id":\s?").+("[,}])`),
+		// the password is the third param of XML-RPC "authenticate" and "execute_kw" calls.
+		xmlPwRe: regexp.MustCompile(`(<methodName>(?:authenticate|execute_kw)</methodName><params>(?:<param><value>.*?</value></param>){2}<param><value><string>)[^<]*(</string>)`),
+		// the password or API key is the third argument of "authenticate" and "execute_kw" calls to "/jsonrpc".
+		keyRe: regexp.MustCompile(`("args":\["(?:[^"\\]|\\.)*",(?:\d+|"(?:[^"\\]|\\.)*"),")(?:[^"\\]|\\.)*(")`),
 	}
 }
 
+func (r redactor) redactRequest(buf []byte) []byte {
+	buf = r.pwRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+	buf = r.xmlPwRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+	buf = r.keyRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+	return r.sessRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+}
+
+func (r redactor) redactResponse(buf []byte) []byte {
+	return r.sessRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+}
+
 func (c Client) useDebugLogger(enabled bool) {
 	if enabled {
-		c.http.Transport = newDebugTransport()
+		c.http.Transport = newDebugTransport(c.http.Transport)
 	}
 }
 
@@ -37,12 +64,11 @@ func (t *debugTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 		reqBody, _ := r.GetBody()
 		defer reqBody.Close()
 		buf, _ := io.ReadAll(reqBody)
-		buf = t.pwRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
-		buf = t.sessRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+		buf = t.redactRequest(buf)
 		logger.V(2).Info(fmt.Sprintf("%s %s ---> %s", r.Method, r.URL.Path, string(buf)))
 	}
 
-	res, err := http.DefaultTransport.RoundTrip(r)
+	res, err := t.next.RoundTrip(r)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +76,7 @@ func (t *debugTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	if res.Body != nil {
 		defer res.Body.Close()
 		buf, _ := io.ReadAll(res.Body)
-		redacted := t.sessRe.ReplaceAll(buf, []byte(confidentialPlaceholder))
+		redacted := t.redactResponse(buf)
 		logger.V(2).Info(fmt.Sprintf("%s %s <--- %s", r.Method, r.URL.Path, string(redacted)))
 		res.Body = io.NopCloser(bytes.NewReader(buf))
 	}
@@ -0,0 +1,175 @@
+// Package domain provides a typed builder for Odoo search domains.
+//
+// Odoo expects domains as a flat list in Polish (prefix) notation, where terms in the form of `[field, operator, value]`
+// are combined with the logical operators "&", "|" and "!".
+// Terms at the top level of a Domain are implicitly combined with AND by Odoo.
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Operator is a comparison operator within a Term.
+type Operator string
+
+const (
+	// OpEqual matches if the field equals the value.
+	OpEqual Operator = "="
+	// OpIn matches if the field equals any value of the given list.
+	OpIn Operator = "in"
+	// OpILike matches if the field contains the value, case-insensitive.
+	OpILike Operator = "ilike"
+	// OpGreaterOrEqual matches if the field is greater than or equal the value.
+	OpGreaterOrEqual Operator = ">="
+	// OpLessOrEqual matches if the field is less than or equal the value.
+	OpLessOrEqual Operator = "<="
+)
+
+const (
+	andOperator = "&"
+	orOperator  = "|"
+	notOperator = "!"
+)
+
+// Criterion is either a Term or a logical combination of other Criterion.
+type Criterion interface {
+	fmt.Stringer
+	// polish returns the criterion flattened in Polish notation.
+	polish() []interface{}
+}
+
+// Domain is a list of Criterion that is serialized into the format expected by Odoo in the `domain` parameter.
+type Domain []Criterion
+
+// New returns a new Domain with the given criteria.
+func New(criteria ...Criterion) Domain {
+	return criteria
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Domain) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Polish())
+}
+
+// Polish returns the Domain flattened into Polish notation.
+func (d Domain) Polish() []interface{} {
+	arr := make([]interface{}, 0, len(d))
+	for _, criterion := range d {
+		arr = append(arr, criterion.polish()...)
+	}
+	return arr
+}
+
+// String implements fmt.Stringer.
+// The returned string is meant for humans, e.g. in debug logs.
+func (d Domain) String() string {
+	parts := make([]string, len(d))
+	for i, criterion := range d {
+		parts[i] = criterion.String()
+	}
+	return "[" + strings.Join(parts, " AND ") + "]"
+}
+
+// Term is a single condition in the form of `[field, operator, value]`.
+type Term struct {
+	// Field is the name of the field of the model, which may also be a dotted path into relations, e.g. `category_id.name`.
+	Field    string
+	Operator Operator
+	Value    interface{}
+}
+
+// Eq returns a Term that matches if the field equals the value.
+func Eq(field string, value interface{}) Term {
+	return Term{Field: field, Operator: OpEqual, Value: value}
+}
+
+// In returns a Term that matches if the field equals any of the given values.
+func In[T any](field string, values ...T) Term {
+	if values == nil {
+		values = []T{}
+	}
+	return Term{Field: field, Operator: OpIn, Value: values}
+}
+
+// ILike returns a Term that matches if the field contains the value, case-insensitive.
+func ILike(field string, value string) Term {
+	return Term{Field: field, Operator: OpILike, Value: value}
+}
+
+// Gte returns a Term that matches if the field is greater than or equal the value.
+func Gte(field string, value interface{}) Term {
+	return Term{Field: field, Operator: OpGreaterOrEqual, Value: value}
+}
+
+// Lte returns a Term that matches if the field is less than or equal the value.
+func Lte(field string, value interface{}) Term {
+	return Term{Field: field, Operator: OpLessOrEqual, Value: value}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Term) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{t.Field, t.Operator, t.Value})
+}
+
+// String implements fmt.Stringer.
+func (t Term) String() string {
+	return fmt.Sprintf("%s %s %#v", t.Field, t.Operator, t.Value)
+}
+
+func (t Term) polish() []interface{} {
+	return []interface{}{t}
+}
+
+type logical struct {
+	operator string
+	criteria []Criterion
+}
+
+// And returns a Criterion that matches if all given criteria match.
+func And(first, second Criterion, more ...Criterion) Criterion {
+	return logical{operator: andOperator, criteria: append([]Criterion{first, second}, more...)}
+}
+
+// Or returns a Criterion that matches if any of the given criteria match.
+func Or(first, second Criterion, more ...Criterion) Criterion {
+	return logical{operator: orOperator, criteria: append([]Criterion{first, second}, more...)}
+}
+
+// Not returns a Criterion that negates the given criterion.
+func Not(criterion Criterion) Criterion {
+	return logical{operator: notOperator, criteria: []Criterion{criterion}}
+}
+
+// String implements fmt.Stringer.
+func (l logical) String() string {
+	if l.operator == notOperator {
+		return "NOT " + l.criteria[0].String()
+	}
+	sep := " AND "
+	if l.operator == orOperator {
+		sep = " OR "
+	}
+	parts := make([]string, len(l.criteria))
+	for i, criterion := range l.criteria {
+		parts[i] = criterion.String()
+	}
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+func (l logical) polish() []interface{} {
+	arr := make([]interface{}, 0)
+	// binary operators need to be repeated to combine more than 2 criteria: "&", "&", A, B, C
+	count := len(l.criteria) - 1
+	if l.operator == notOperator {
+		count = 1
+	}
+	for i := 0; i < count; i++ {
+		arr = append(arr, l.operator)
+	}
+	for _, criterion := range l.criteria {
+		arr = append(arr, criterion.polish()...)
+	}
+	return arr
+}
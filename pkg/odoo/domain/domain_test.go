@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomain_MarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		givenDomain  Domain
+		expectedJSON string
+	}{
+		"GivenEmptyDomain_ThenExpectEmptyArray": {
+			givenDomain:  New(),
+			expectedJSON: `[]`,
+		},
+		"GivenSingleTerm_ThenExpectTermArray": {
+			givenDomain:  New(Eq("user_id", 1)),
+			expectedJSON: `[["user_id","=",1]]`,
+		},
+		"GivenMultipleTerms_ThenExpectImplicitAnd": {
+			givenDomain:  New(Eq("name", "Manager"), Eq("category_id.name", "Human Resources")),
+			expectedJSON: `[["name","=","Manager"],["category_id.name","=","Human Resources"]]`,
+		},
+		"GivenInTerm_ThenExpectList": {
+			givenDomain:  New(In("state", "confirm", "validate")),
+			expectedJSON: `[["state","in",["confirm","validate"]]]`,
+		},
+		"GivenInTerm_WhenNoValues_ThenExpectEmptyList": {
+			givenDomain:  New(In[int]("id")),
+			expectedJSON: `[["id","in",[]]]`,
+		},
+		"GivenILikeAndGte_ThenExpectOperators": {
+			givenDomain:  New(ILike("name", "Salary Slip"), Gte("date_from", "2022-01-01"), Lte("date_to", "2022-01-31")),
+			expectedJSON: `[["name","ilike","Salary Slip"],["date_from",">=","2022-01-01"],["date_to","<=","2022-01-31"]]`,
+		},
+		"GivenOr_WhenMoreThanTwoCriteria_ThenRepeatOperator": {
+			givenDomain:  New(Or(Eq("a", 1), Eq("b", 2), Eq("c", 3))),
+			expectedJSON: `["|","|",["a","=",1],["b","=",2],["c","=",3]]`,
+		},
+		"GivenNot_ThenExpectPrefix": {
+			givenDomain:  New(Not(And(Eq("a", 1), Eq("b", 2)))),
+			expectedJSON: `["!","&",["a","=",1],["b","=",2]]`,
+		},
+		"GivenNestedCriteria_ThenExpectPolishNotation": {
+			givenDomain: New(
+				Eq("type", "remove"),
+				Eq("employee_id", 1),
+				Or(
+					And(Gte("date_from", "2022-01-01"), Lte("date_from", "2022-01-31")),
+					And(Lte("date_from", "2022-01-01"), Gte("date_to", "2022-01-01")),
+					And(Lte("date_from", "2022-01-31"), Gte("date_to", "2022-01-01")),
+				),
+			),
+			expectedJSON: `[
+				["type","=","remove"],
+				["employee_id","=",1],
+				"|","|",
+				"&",["date_from",">=","2022-01-01"],["date_from","<=","2022-01-31"],
+				"&",["date_from","<=","2022-01-01"],["date_to",">=","2022-01-01"],
+				"&",["date_from","<=","2022-01-31"],["date_to",">=","2022-01-01"]
+			]`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := json.Marshal(tc.givenDomain)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedJSON, string(result))
+		})
+	}
+}
+
+func TestDomain_String(t *testing.T) {
+	tests := map[string]struct {
+		givenDomain    Domain
+		expectedString string
+	}{
+		"GivenEmptyDomain": {
+			givenDomain:    New(),
+			expectedString: `[]`,
+		},
+		"GivenMultipleTerms": {
+			givenDomain:    New(Eq("user_id", 1), ILike("name", "Salary")),
+			expectedString: `[user_id = 1 AND name ilike "Salary"]`,
+		},
+		"GivenNestedCriteria": {
+			givenDomain:    New(Not(Or(Eq("a", 1), And(Eq("b", 2), In("c", 3, 4))))),
+			expectedString: `[NOT (a = 1 OR (b = 2 AND c in []int{3, 4}))]`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.givenDomain.String())
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// externalCall calls a method of a service ("common" or "object") of the external API of Odoo.
+type externalCall func(ctx context.Context, service, method string, into interface{}, args ...interface{}) error
+
+// externalAPI implements QueryExecutor on top of the external API of Odoo, which is available via XML-RPC and JSON-RPC.
+// Each call is authenticated with the user ID and a secret, which is either the user's password or an API key.
+type externalAPI struct {
+	// UID is the user's ID.
+	UID      int
+	database string
+	secret   string
+	call     externalCall
+	// retryPolicy applies to "search_read" calls.
+	retryPolicy RetryPolicy
+}
+
+// authenticateExternal authenticates the user with the "common" service.
+// It returns ErrInvalidCredentials if the credentials were wrong.
+func authenticateExternal(ctx context.Context, call externalCall, retryPolicy RetryPolicy, database, login, secret string) (externalAPI, error) {
+	var uid interface{}
+	if err := call(ctx, "common", "authenticate", &uid, database, login, secret, map[string]interface{}{}); err != nil {
+		return externalAPI{}, fmt.Errorf("login: %w", err)
+	}
+	// Odoo returns `false` on wrong credentials
+	id, isNumber := uid.(float64)
+	if !isNumber {
+		return externalAPI{}, ErrInvalidCredentials
+	}
+	return externalAPI{UID: int(id), database: database, secret: secret, call: call, retryPolicy: retryPolicy}, nil
+}
+
+// SearchGenericModel implements QueryExecutor.
+// The result is shaped like the result of the JSON-RPC "search_read" endpoint, so that the same models can be used with both protocols.
+// The "search_read" call is retried according to ClientOptions.RetryPolicy if it fails with a transient error.
+// The "length" of a paged result is only the total amount of records if SearchReadModel.CountTotal is set, as it requires an additional "search_count" call.
+func (a externalAPI) SearchGenericModel(ctx context.Context, model SearchReadModel, into interface{}) error {
+	kwargs := map[string]interface{}{}
+	if len(model.Fields) > 0 {
+		kwargs["fields"] = model.Fields
+	}
+	if model.Limit > 0 {
+		kwargs["limit"] = model.Limit
+	}
+	if model.Offset > 0 {
+		kwargs["offset"] = model.Offset
+	}
+	if model.Sort != "" {
+		kwargs["order"] = model.Sort
+	}
+	records := make([]json.RawMessage, 0)
+	err := a.retryPolicy.do(ctx, model.Model, func() error {
+		return a.executeKW(ctx, model.Model, "search_read", []interface{}{model.Domain}, kwargs, &records)
+	})
+	if err != nil {
+		return err
+	}
+	length := len(records)
+	if model.CountTotal && (model.Limit > 0 || model.Offset > 0) {
+		if err := a.executeKW(ctx, model.Model, "search_count", []interface{}{model.Domain}, map[string]interface{}{}, &length); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"records": records,
+		"length":  length,
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, into)
+}
+
+// CreateGenericModel implements QueryExecutor.
+func (a externalAPI) CreateGenericModel(ctx context.Context, model string, data interface{}) (int, error) {
+	var id int
+	err := a.executeKW(ctx, model, string(MethodCreate), []interface{}{data}, map[string]interface{}{}, &id)
+	return id, err
+}
+
+// UpdateGenericModel implements QueryExecutor.
+func (a externalAPI) UpdateGenericModel(ctx context.Context, model string, id int, data interface{}) error {
+	if id == 0 {
+		return fmt.Errorf("id cannot be zero: %v", data)
+	}
+	updated := false
+	return a.executeKW(ctx, model, string(MethodWrite), []interface{}{[]int{id}, data}, map[string]interface{}{}, &updated)
+}
+
+// DeleteGenericModel implements QueryExecutor.
+func (a externalAPI) DeleteGenericModel(ctx context.Context, model string, ids []int) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("slice of ID(s) is required")
+	}
+	for i, id := range ids {
+		if id == 0 {
+			return fmt.Errorf("id cannot be zero (index: %d)", i)
+		}
+	}
+	deleted := false
+	return a.executeKW(ctx, model, string(MethodDelete), []interface{}{ids}, map[string]interface{}{}, &deleted)
+}
+
+// ReadGroup implements QueryExecutor.
+func (a externalAPI) ReadGroup(ctx context.Context, model ReadGroupModel) ([]ReadGroupResult, error) {
+	raw := make([]map[string]json.RawMessage, 0)
+	if err := a.executeKW(ctx, model.Model, string(MethodReadGroup), []interface{}{}, readGroupKWArgs(model), &raw); err != nil {
+		return nil, err
+	}
+	return decodeReadGroupResults(raw, model)
+}
+
+// ExecuteQuery implements QueryExecutor.
+// The JSON-RPC paths are translated to calls of the external API:
+// "/web/dataset/search_read" requires a SearchReadModel and "/web/dataset/call_kw/*" requires a WriteModel.
+// Other paths aren't supported.
+func (a externalAPI) ExecuteQuery(ctx context.Context, path string, model interface{}, into interface{}) error {
+	switch m := model.(type) {
+	case SearchReadModel:
+		if path == "/web/dataset/search_read" {
+			return a.SearchGenericModel(ctx, m, into)
+		}
+	case WriteModel:
+		if strings.HasPrefix(path, "/web/dataset/call_kw/") {
+			return a.executeKW(ctx, m.Model, string(m.Method), m.Args, m.KWArgs, into)
+		}
+	}
+	return fmt.Errorf("external api: unsupported query %T to %q", model, path)
+}
+
+// executeKW calls the given method of the model with "execute_kw".
+func (a externalAPI) executeKW(ctx context.Context, model, method string, args []interface{}, kwargs map[string]interface{}, into interface{}) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+	if kwargs == nil {
+		kwargs = map[string]interface{}{}
+	}
+	err := a.call(ctx, "object", "execute_kw", into, a.database, a.UID, a.secret, model, method, args, kwargs)
+	return withQuery(err, model, method)
+}
@@ -0,0 +1,91 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultPageSize is the amount of records fetched per request when paging through search results.
+const DefaultPageSize = 200
+
+// defaultPageSort makes sure that the records don't shift between pages.
+const defaultPageSort = "id ASC"
+
+// Iterate pages through all records matching the given SearchReadModel and calls fn for each record.
+//
+// Each page is fetched with a separate request containing pageSize records (DefaultPageSize if <= 0).
+// SearchReadModel.Offset is the offset of the first page and if SearchReadModel.Limit is set, it caps the total amount of records.
+// If SearchReadModel.Sort is empty, records are sorted by ID to get stable pages.
+//
+// Iteration stops at the first error returned by fn or the querier, or if the context is cancelled.
+func Iterate[T any](ctx context.Context, querier QueryExecutor, model SearchReadModel, pageSize int, fn func(T) error) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if model.Sort == "" {
+		model.Sort = defaultPageSort
+	}
+	total := model.Limit
+	offset := model.Offset
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page := model
+		page.Offset = offset
+		page.Limit = pageSize
+		if total > 0 && total-count < pageSize {
+			page.Limit = total - count
+		}
+		result := List[T]{}
+		if err := querier.SearchGenericModel(ctx, page, &result); err != nil {
+			return fmt.Errorf("fetching page at offset %d: %w", offset, err)
+		}
+		for _, item := range result.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		count += result.Len()
+		offset += result.Len()
+		if result.Len() < page.Limit || (total > 0 && count >= total) {
+			return nil
+		}
+	}
+}
+
+// SearchAll is like Iterate, but collects all records in a List.
+func SearchAll[T any](ctx context.Context, querier QueryExecutor, model SearchReadModel, pageSize int) (List[T], error) {
+	result := List[T]{}
+	err := Iterate(ctx, querier, model, pageSize, func(item T) error {
+		result.Items = append(result.Items, item)
+		return nil
+	})
+	return result, err
+}
+
+// Stream is like Iterate, but yields the records through a channel in a separate goroutine.
+// Both channels are closed once the iteration is done.
+// The error channel receives at most one error.
+// Cancel the context to stop the iteration early.
+func Stream[T any](ctx context.Context, querier QueryExecutor, model SearchReadModel, pageSize int) (<-chan T, <-chan error) {
+	records := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(records)
+		err := Iterate(ctx, querier, model, pageSize, func(item T) error {
+			select {
+			case records <- item:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return records, errs
+}
@@ -0,0 +1,127 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedQuerier serves SearchGenericModel from a fixed list of records.
+type pagedQuerier struct {
+	QueryExecutor
+	records  []int
+	requests []SearchReadModel
+}
+
+func (q *pagedQuerier) SearchGenericModel(_ context.Context, model SearchReadModel, into interface{}) error {
+	q.requests = append(q.requests, model)
+	end := model.Offset + model.Limit
+	if model.Limit == 0 || end > len(q.records) {
+		end = len(q.records)
+	}
+	start := model.Offset
+	if start > end {
+		start = end
+	}
+	b, err := json.Marshal(List[int]{Items: q.records[start:end]})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, into)
+}
+
+func TestSearchAll(t *testing.T) {
+	tests := map[string]struct {
+		givenRecords     []int
+		givenLimit       int
+		givenOffset      int
+		givenPageSize    int
+		expectedItems    []int
+		expectedRequests int
+	}{
+		"GivenNoRecords_ThenExpectSingleRequest": {
+			givenRecords:     []int{},
+			givenPageSize:    2,
+			expectedRequests: 1,
+		},
+		"GivenRecords_WhenLessThanPageSize_ThenExpectSingleRequest": {
+			givenRecords:     []int{1, 2, 3},
+			givenPageSize:    5,
+			expectedItems:    []int{1, 2, 3},
+			expectedRequests: 1,
+		},
+		"GivenRecords_WhenMultiplePages_ThenExpectAllRecords": {
+			givenRecords:     []int{1, 2, 3, 4, 5},
+			givenPageSize:    2,
+			expectedItems:    []int{1, 2, 3, 4, 5},
+			expectedRequests: 3,
+		},
+		"GivenRecords_WhenExactlyFullPages_ThenExpectEmptyLastPage": {
+			givenRecords:     []int{1, 2, 3, 4},
+			givenPageSize:    2,
+			expectedItems:    []int{1, 2, 3, 4},
+			expectedRequests: 3,
+		},
+		"GivenRecords_WhenLimitAndOffsetSet_ThenRespectBoth": {
+			givenRecords:     []int{1, 2, 3, 4, 5, 6, 7},
+			givenOffset:      1,
+			givenLimit:       3,
+			givenPageSize:    2,
+			expectedItems:    []int{2, 3, 4},
+			expectedRequests: 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			querier := &pagedQuerier{records: tc.givenRecords}
+			result, err := SearchAll[int](context.Background(), querier, SearchReadModel{Limit: tc.givenLimit, Offset: tc.givenOffset}, tc.givenPageSize)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedItems, result.Items)
+			assert.Len(t, querier.requests, tc.expectedRequests)
+			for _, request := range querier.requests {
+				assert.Equal(t, defaultPageSort, request.Sort)
+			}
+		})
+	}
+}
+
+func TestIterate_WhenCallbackFails_ThenStop(t *testing.T) {
+	querier := &pagedQuerier{records: []int{1, 2, 3, 4, 5}}
+	visited := make([]int, 0)
+	err := Iterate(context.Background(), querier, SearchReadModel{}, 2, func(i int) error {
+		visited = append(visited, i)
+		if i == 3 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, []int{1, 2, 3}, visited)
+	assert.Len(t, querier.requests, 2)
+}
+
+func TestIterate_WhenContextCancelled_ThenStop(t *testing.T) {
+	querier := &pagedQuerier{records: []int{1, 2, 3, 4, 5}}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Iterate(ctx, querier, SearchReadModel{}, 2, func(i int) error {
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, querier.requests, 1)
+}
+
+func TestStream(t *testing.T) {
+	querier := &pagedQuerier{records: []int{1, 2, 3, 4, 5}}
+	records, errs := Stream[int](context.Background(), querier, SearchReadModel{}, 2)
+	result := make([]int, 0)
+	for record := range records {
+		result = append(result, record)
+	}
+	assert.NoError(t, <-errs)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, result)
+}
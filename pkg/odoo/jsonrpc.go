@@ -3,6 +3,7 @@ package odoo
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -72,7 +73,11 @@ type JSONRPCError struct {
 	Data    map[string]interface{} `json:"data,omitempty"`
 }
 
+// sessionExpiredCode is the JSON-RPC error code that Odoo returns if the session is invalid or expired.
+const sessionExpiredCode = 100
+
 // DecodeResult takes a buffer, decodes the intermediate JSONRPCResponse and then the contained "result" field into "result".
+// ErrSessionExpired is returned if Odoo responds with its session-expired error code, otherwise errors are returned as *RPCError.
 func DecodeResult(buf io.Reader, result interface{}) error {
 	// Decode intermediate
 	var res JSONRPCResponse
@@ -80,7 +85,10 @@ func DecodeResult(buf io.Reader, result interface{}) error {
 		return fmt.Errorf("decode intermediate: %w", err)
 	}
 	if res.Error != nil {
-		return fmt.Errorf("%s: %s", res.Error.Message, res.Error.Data["message"])
+		if res.Error.Code == sessionExpiredCode {
+			return ErrSessionExpired
+		}
+		return newRPCError(res.Error)
 	}
 
 	return json.Unmarshal(*res.Result, result)
@@ -90,6 +98,16 @@ func newEncodingRequestError(err error) error {
 	return fmt.Errorf("encoding request: %w", err)
 }
 
+// newDecodingResultError wraps errors that occurred while decoding a response.
+// Errors returned by Odoo are passed through, since they aren't decoding failures.
+func newDecodingResultError(err error) error {
+	rpcErr := &RPCError{}
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+	return fmt.Errorf("decoding result: %w", err)
+}
+
 func newCreatingRequestError(err error) error {
 	return fmt.Errorf("creating request: %w", err)
 }
@@ -1,18 +1,22 @@
 package odoo
 
+import "github.com/vshn/odootools/pkg/odoo/domain"
+
 // SearchReadModel is used as "params" in requests to "dataset/search_read" endpoints.
 type SearchReadModel struct {
-	Model  string   `json:"model,omitempty"`
-	Domain []Filter `json:"domain,omitempty"`
-	Fields []string `json:"fields,omitempty"`
-	Limit  int      `json:"limit,omitempty"`
-	Offset int      `json:"offset,omitempty"`
+	Model string `json:"model,omitempty"`
+	// Domain filters the records, e.g. `domain.New(domain.Eq("employee_id.user_id.id", 123))`.
+	Domain domain.Domain `json:"domain,omitempty"`
+	Fields []string      `json:"fields,omitempty"`
+	Limit  int           `json:"limit,omitempty"`
+	Offset int           `json:"offset,omitempty"`
+	// Sort is the order of the records, e.g. "id ASC".
+	Sort string `json:"sort,omitempty"`
+	// CountTotal requests the total amount of matching records as "length" of the result, even if Limit or Offset are set.
+	// The "search_read" endpoint of the web client always includes it, whereas the external API needs an additional request.
+	CountTotal bool `json:"-"`
 }
 
-// Filter to use in queries, usually in the format of
-// [predicate, operator, value], eg ["employee_id.user_id.id", "=", 123]
-type Filter interface{}
-
 // Method identifies the type of write operation.
 type Method string
 
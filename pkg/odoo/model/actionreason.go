@@ -1,6 +1,11 @@
 package model
 
-import "encoding/json"
+import (
+	"context"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
 
 // ActionReason describes the "action reason" from Odoo.
 // Example raw values returned from Odoo:
@@ -13,50 +18,32 @@ import "encoding/json"
 //   - `[6, "Authorities"]`
 //   - `[27, "Requested Public Service"]`
 //   - `[28, "Requested Public Service"]`
-type ActionReason struct {
-	ID   float64
-	Name string
-}
+type ActionReason = odoo.Many2One
 
 const (
 	ActionSignOut = "sign_out"
 	ActionSignIn  = "sign_in"
 )
 
-// String implements fmt.Stringer.
-func (reason *ActionReason) String() string {
-	if reason == nil {
-		return ""
-	}
-	return reason.Name
-}
-
-// MarshalJSON implements json.Marshaler.
-func (reason ActionReason) MarshalJSON() ([]byte, error) {
-	if reason.Name == "" {
-		return []byte("false"), nil
-	}
-	arr := []interface{}{reason.ID, reason.Name}
-	return json.Marshal(arr)
-}
-
-// UnmarshalJSON implements json.Unmarshaler.
-func (reason *ActionReason) UnmarshalJSON(b []byte) error {
-	var f bool
-	if err := json.Unmarshal(b, &f); err == nil || string(b) == "false" {
-		return nil
-	}
-	var arr []interface{}
-	if err := json.Unmarshal(b, &arr); err != nil {
-		return err
+// FetchActionReasons returns the reasons that can be given to attendances with the given action.
+// Odoo has a separate reason record for each action, even if the names are the same.
+// Odoo 10 and later don't have reasons, an empty list is returned.
+func (o Odoo) FetchActionReasons(ctx context.Context, action string) (odoo.List[ActionReason], error) {
+	result := odoo.List[ActionReason]{Items: []ActionReason{}}
+	if o.adapter.attendanceShifts {
+		return result, nil
 	}
-	if len(arr) >= 2 {
-		if v, ok := arr[1].(string); ok {
-			*reason = ActionReason{
-				ID:   arr[0].(float64),
-				Name: v,
-			}
-		}
+	records := odoo.List[struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}]{}
+	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
+		Model:  "hr.action.reason",
+		Domain: domain.New(domain.Eq("action_type", action)),
+		Fields: []string{"name"},
+	}, &records)
+	for _, record := range records.Items {
+		result.Items = append(result.Items, ActionReason{ID: record.ID, Name: record.Name})
 	}
-	return nil
+	return result, err
 }
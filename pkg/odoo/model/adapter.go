@@ -0,0 +1,47 @@
+package model
+
+import "github.com/vshn/odootools/pkg/odoo"
+
+// adapter maps the types of this package to the models and fields of a specific Odoo version.
+// The types are modelled after Odoo 8, newer versions are converted to the same shapes, so that the timesheet package works regardless of the Odoo version.
+type adapter struct {
+	// attendanceShifts is true if each "hr.attendance" record is a shift with "check_in" and "check_out" (Odoo 10 and later),
+	// instead of a sign_in or sign_out event with its timestamp in "name".
+	attendanceShifts bool
+	// leaveModel is "hr.holidays" until Odoo 11 and "hr.leave" since Odoo 12.
+	// Until Odoo 11, "hr.holidays" also contains the allocations, which have the "type" "add".
+	leaveModel string
+	// allocationModel is "hr.holidays" until Odoo 11 and "hr.leave.allocation" since Odoo 12.
+	allocationModel string
+	// allocationDaysField is "number_of_days_temp" until Odoo 11 and "number_of_days" since Odoo 12.
+	allocationDaysField string
+	// contractScheduleField is "working_hours" until Odoo 10 and "resource_calendar_id" since Odoo 11.
+	contractScheduleField string
+}
+
+// newAdapter returns the adapter for the given Odoo version.
+// Odoo 8 is assumed if the version is unknown.
+func newAdapter(version odoo.ServerVersion) adapter {
+	major := version.Major()
+	a := adapter{
+		attendanceShifts:      major >= 10,
+		leaveModel:            "hr.holidays",
+		allocationModel:       "hr.holidays",
+		allocationDaysField:   "number_of_days_temp",
+		contractScheduleField: "working_hours",
+	}
+	if major >= 11 {
+		a.contractScheduleField = "resource_calendar_id"
+	}
+	if major >= 12 {
+		a.leaveModel = "hr.leave"
+		a.allocationModel = "hr.leave.allocation"
+		a.allocationDaysField = "number_of_days"
+	}
+	return a
+}
+
+// hasLeaveTypes returns true if the leave model contains both leaves and allocations.
+func (a adapter) hasLeaveTypes() bool {
+	return a.leaveModel == "hr.holidays"
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/odootest"
+)
+
+func newOdoo16Fixtures() odootest.Fixtures {
+	employee := []interface{}{2, "Jane Doe"}
+	return odootest.Fixtures{
+		ServerVersion: "16.0",
+		Models: map[string][]odootest.Record{
+			"res.users": {
+				{"id": 1, "name": "Jane Doe", "login": "jane", "password": "secret"},
+			},
+			"hr.attendance": {
+				{"id": 1, "employee_id": employee, "check_in": "2022-02-28 22:00:00", "check_out": "2022-03-01 02:00:00"},
+				{"id": 2, "employee_id": employee, "check_in": "2022-03-01 07:00:00", "check_out": "2022-03-01 11:00:00"},
+				{"id": 3, "employee_id": employee, "check_in": "2022-03-01 12:00:00", "check_out": false},
+				{"id": 4, "employee_id": employee, "check_in": "2022-03-31 20:00:00", "check_out": "2022-04-01 01:00:00"},
+			},
+			"hr.leave": {
+				{"id": 1, "employee_id": employee, "state": "validate", "holiday_status_id": []interface{}{16, "Legal Leaves 2022"},
+					"date_from": "2022-03-02 23:00:00", "date_to": "2022-03-03 22:59:59"},
+			},
+			"hr.leave.allocation": {
+				{"id": 1, "employee_id": employee, "state": "validate", "holiday_status_id": []interface{}{16, "Legal Leaves 2022"}, "number_of_days": 25.0},
+			},
+			"hr.contract": {
+				{"id": 1, "employee_id": employee, "date_start": "2021-01-01", "date_end": false, "resource_calendar_id": []interface{}{1, "Standard 100% Work Week"}},
+			},
+		},
+	}
+}
+
+func newOdoo16(t *testing.T) *Odoo {
+	server := odootest.NewServer(newOdoo16Fixtures())
+	t.Cleanup(server.Close)
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	version, err := client.FetchVersion(context.Background())
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	return NewOdooForVersion(session, version)
+}
+
+func TestNewAdapter(t *testing.T) {
+	tests := map[string]struct {
+		givenVersion    string
+		expectedAdapter adapter
+	}{
+		"GivenUnknownVersion_ThenAssumeOdoo8": {
+			givenVersion:    "",
+			expectedAdapter: adapter{leaveModel: "hr.holidays", allocationModel: "hr.holidays", allocationDaysField: "number_of_days_temp", contractScheduleField: "working_hours"},
+		},
+		"GivenOdoo10_ThenUseAttendanceShifts": {
+			givenVersion:    "10.0",
+			expectedAdapter: adapter{attendanceShifts: true, leaveModel: "hr.holidays", allocationModel: "hr.holidays", allocationDaysField: "number_of_days_temp", contractScheduleField: "working_hours"},
+		},
+		"GivenOdoo11_ThenUseResourceCalendar": {
+			givenVersion:    "11.0",
+			expectedAdapter: adapter{attendanceShifts: true, leaveModel: "hr.holidays", allocationModel: "hr.holidays", allocationDaysField: "number_of_days_temp", contractScheduleField: "resource_calendar_id"},
+		},
+		"GivenOdoo16_ThenUseLeaveModel": {
+			givenVersion:    "16.0+e",
+			expectedAdapter: adapter{attendanceShifts: true, leaveModel: "hr.leave", allocationModel: "hr.leave.allocation", allocationDaysField: "number_of_days", contractScheduleField: "resource_calendar_id"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := newAdapter(odoo.ServerVersion{Version: tc.givenVersion})
+			assert.Equal(t, tc.expectedAdapter, result)
+		})
+	}
+}
+
+func TestOdoo_FetchAttendancesBetweenDates_GivenOdoo16_ThenSplitShifts(t *testing.T) {
+	o := newOdoo16(t)
+	begin := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := o.FetchAttendancesBetweenDates(context.Background(), 2, begin, end)
+	require.NoError(t, err)
+
+	expected := []Attendance{
+		{ID: 1, DateTime: odoo.NewDate(2022, time.March, 1, 2, 0, 0, time.UTC), Action: ActionSignOut},
+		{ID: 2, DateTime: odoo.NewDate(2022, time.March, 1, 7, 0, 0, time.UTC), Action: ActionSignIn},
+		{ID: 2, DateTime: odoo.NewDate(2022, time.March, 1, 11, 0, 0, time.UTC), Action: ActionSignOut},
+		{ID: 3, DateTime: odoo.NewDate(2022, time.March, 1, 12, 0, 0, time.UTC), Action: ActionSignIn},
+		{ID: 4, DateTime: odoo.NewDate(2022, time.March, 31, 20, 0, 0, time.UTC), Action: ActionSignIn},
+	}
+	require.Len(t, result.Items, len(expected))
+	for i, attendance := range result.Items {
+		assert.Equal(t, expected[i].ID, attendance.ID, "ID of item %d", i)
+		assert.Equal(t, expected[i].Action, attendance.Action, "action of item %d", i)
+		assert.True(t, expected[i].DateTime.Equal(attendance.DateTime.Time), "expected %s, got %s", expected[i].DateTime, attendance.DateTime)
+	}
+}
+
+func TestOdoo_FetchLeavesBetweenDates_GivenOdoo16_ThenQueryLeaveModel(t *testing.T) {
+	o := newOdoo16(t)
+	begin := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	result, err := o.FetchLeavesBetweenDates(context.Background(), 2, begin, end)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Legal Leaves 2022", result.Items[0].Type.Name)
+	assert.Equal(t, "validate", result.Items[0].State)
+}
+
+func TestOdoo_FetchAllocations_GivenOdoo16_ThenQueryAllocationModel(t *testing.T) {
+	o := newOdoo16(t)
+
+	result, err := o.FetchAllocations(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "Legal Leaves 2022", result.Items[0].Type.Name)
+	assert.Equal(t, 25.0, result.Items[0].Days)
+}
+
+func TestOdoo_FetchAllContractsOfEmployee_GivenOdoo16_ThenReadResourceCalendar(t *testing.T) {
+	o := newOdoo16(t)
+
+	result, err := o.FetchAllContractsOfEmployee(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.NotNil(t, result.Items[0].WorkingSchedule)
+	assert.Equal(t, "Standard 100% Work Week", result.Items[0].WorkingSchedule.Name)
+	ratio, err := result.GetFTERatioForDay(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, ratio)
+}
+
+func TestOdoo_CreateUpdateDeleteAttendance_GivenOdoo16_ThenWriteShifts(t *testing.T) {
+	server := odootest.NewServer(newOdoo16Fixtures())
+	t.Cleanup(server.Close)
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	o := NewOdooForVersion(session, odoo.ServerVersion{Version: "16.0"})
+	ctx := context.Background()
+
+	// shift 3 has no check_out yet
+	id, err := o.CreateAttendance(ctx, 2, Attendance{DateTime: odoo.NewDate(2022, time.March, 1, 17, 0, 0, time.UTC), Action: ActionSignOut})
+	require.NoError(t, err)
+	assert.Equal(t, 3, id)
+	shift, _ := server.Record("hr.attendance", 3)
+	assert.Equal(t, "2022-03-01 17:00:00", shift["check_out"])
+
+	err = o.UpdateAttendance(ctx, Attendance{ID: 3, DateTime: odoo.NewDate(2022, time.March, 1, 13, 0, 0, time.UTC), Action: ActionSignIn})
+	require.NoError(t, err)
+	shift, _ = server.Record("hr.attendance", 3)
+	assert.Equal(t, "2022-03-01 13:00:00", shift["check_in"])
+	assert.Equal(t, "2022-03-01 17:00:00", shift["check_out"])
+
+	err = o.DeleteAttendance(ctx, Attendance{ID: 3, Action: ActionSignOut})
+	require.NoError(t, err)
+	shift, found := server.Record("hr.attendance", 3)
+	require.True(t, found, "deleting the sign_out should reopen the shift")
+	assert.Equal(t, false, shift["check_out"])
+
+	id, err = o.CreateAttendance(ctx, 2, Attendance{DateTime: odoo.NewDate(2022, time.March, 2, 8, 0, 0, time.UTC), Action: ActionSignIn})
+	require.NoError(t, err)
+	shift, _ = server.Record("hr.attendance", id)
+	assert.Equal(t, "2022-03-02 08:00:00", shift["check_in"])
+
+	err = o.DeleteAttendance(ctx, Attendance{ID: id, Action: ActionSignIn})
+	require.NoError(t, err)
+	_, found = server.Record("hr.attendance", id)
+	assert.False(t, found)
+}
+
+func TestOdoo_FetchActionReasons_GivenOdoo16_ThenReturnEmptyList(t *testing.T) {
+	o := newOdoo16(t)
+
+	result, err := o.FetchActionReasons(context.Background(), ActionSignIn)
+	require.NoError(t, err)
+	assert.Empty(t, result.Items)
+}
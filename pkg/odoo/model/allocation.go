@@ -0,0 +1,51 @@
+package model
+
+import (
+	"context"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+// Allocation adds days of a leave type to the holiday budget of an employee.
+type Allocation struct {
+	// ID is an unique ID for each allocation
+	ID int `json:"id"`
+
+	// Type describes the "leave type" from Odoo, e.g. `[16, "Legal Leaves 2020"]`.
+	Type *LeaveType `json:"holiday_status_id,omitempty"`
+
+	// Days is the number of allocated days.
+	Days float64 `json:"-"`
+
+	// State is the allocation state, it has the same values as Leave.State.
+	State string `json:"state,omitempty"`
+}
+
+// allocation is an Allocation with the number of days in the field of the Odoo version.
+type allocation struct {
+	Allocation
+	NumberOfDaysTemp float64 `json:"number_of_days_temp"`
+	NumberOfDays     float64 `json:"number_of_days"`
+}
+
+// FetchAllocations returns all allocations of the given employee, regardless of their state.
+func (o Odoo) FetchAllocations(ctx context.Context, employeeID int) (odoo.List[Allocation], error) {
+	filters := domain.New(domain.Eq("employee_id", employeeID))
+	if o.adapter.hasLeaveTypes() {
+		// Allocations are stored together with the leaves.
+		filters = append(domain.New(domain.Eq("type", "add")), filters...)
+	}
+	allocations := odoo.List[allocation]{}
+	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
+		Model:  o.adapter.allocationModel,
+		Domain: filters,
+		Fields: []string{"holiday_status_id", "state", o.adapter.allocationDaysField},
+	}, &allocations)
+	result := odoo.List[Allocation]{Items: make([]Allocation, len(allocations.Items))}
+	for i, a := range allocations.Items {
+		result.Items[i] = a.Allocation
+		result.Items[i].Days = a.NumberOfDaysTemp + a.NumberOfDays
+	}
+	return result, err
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/odootest"
+)
+
+func TestOdoo_FetchAllocations(t *testing.T) {
+	server := odootest.NewServer(odootest.DefaultFixtures())
+	defer server.Close()
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	o := NewOdoo(session)
+	ctx := context.Background()
+
+	allocations, err := o.FetchAllocations(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, allocations.Items, 1, "leaves of type 'remove' shouldn't be returned")
+	assert.Equal(t, 16, allocations.Items[0].Type.ID)
+	assert.Equal(t, 25.0, allocations.Items[0].Days)
+	assert.Equal(t, "validate", allocations.Items[0].State)
+
+	leaves, err := o.FetchLeavesOfTypes(ctx, 2, []int{16})
+	require.NoError(t, err)
+	assert.Len(t, leaves.Items, 2, "allocations shouldn't be returned")
+
+	leaves, err = o.FetchLeavesOfTypes(ctx, 2, []int{})
+	require.NoError(t, err)
+	assert.Empty(t, leaves.Items)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 // Attendance is an entry or closing event of a shift.
@@ -35,26 +36,147 @@ func (a Attendance) String() string {
 
 // FetchAttendancesBetweenDates retrieves all attendances associated with the given employee between 2 dates (inclusive each).
 func (o Odoo) FetchAttendancesBetweenDates(ctx context.Context, employeeID int, begin, end time.Time) (AttendanceList, error) {
-	return o.fetchAttendances(ctx, []odoo.Filter{
-		[]interface{}{"employee_id", "=", employeeID},
-		[]string{"name", ">=", begin.Format(odoo.DateFormat)},
-		[]string{"name", "<=", end.Format(odoo.DateFormat)},
-	})
+	if o.adapter.attendanceShifts {
+		return o.fetchAttendanceShifts(ctx, employeeID, begin.Format(odoo.DateFormat), end.Format(odoo.DateFormat))
+	}
+	return o.fetchAttendances(ctx, domain.New(
+		domain.Eq("employee_id", employeeID),
+		domain.Gte("name", begin.Format(odoo.DateFormat)),
+		domain.Lte("name", end.Format(odoo.DateFormat)),
+	))
 }
 
-func (o Odoo) fetchAttendances(ctx context.Context, domainFilters []odoo.Filter) (AttendanceList, error) {
-	result := AttendanceList{}
-	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
+// attendanceShift is an "hr.attendance" record of Odoo 10 and later.
+type attendanceShift struct {
+	ID       int       `json:"id"`
+	CheckIn  odoo.Date `json:"check_in"`
+	CheckOut odoo.Date `json:"check_out"`
+}
+
+// fetchAttendanceShifts retrieves the shifts that start or end between the given dates and splits them into sign_in and sign_out attendances.
+// Like the "name" of Odoo 8 attendances, only the check-ins and check-outs between the dates are returned.
+func (o Odoo) fetchAttendanceShifts(ctx context.Context, employeeID int, begin, end string) (AttendanceList, error) {
+	shifts, err := odoo.SearchAll[attendanceShift](ctx, o.querier, odoo.SearchReadModel{
+		Model: "hr.attendance",
+		Domain: domain.New(
+			domain.Eq("employee_id", employeeID),
+			domain.Or(
+				domain.And(domain.Gte("check_in", begin), domain.Lte("check_in", end)),
+				domain.And(domain.Gte("check_out", begin), domain.Lte("check_out", end)),
+			),
+		),
+		Fields: []string{"employee_id", "check_in", "check_out"},
+	}, odoo.DefaultPageSize)
+	// Odoo compares the timestamps as strings with the dates in the domain, do the same for consistent results.
+	isBetween := func(date odoo.Date) bool {
+		formatted := date.Format(odoo.DateTimeFormat)
+		return !date.IsZero() && formatted >= begin && formatted <= end
+	}
+	result := AttendanceList{Items: []Attendance{}}
+	for _, shift := range shifts.Items {
+		if isBetween(shift.CheckIn) {
+			result.Items = append(result.Items, Attendance{ID: shift.ID, DateTime: shift.CheckIn, Action: ActionSignIn})
+		}
+		if isBetween(shift.CheckOut) {
+			result.Items = append(result.Items, Attendance{ID: shift.ID, DateTime: shift.CheckOut, Action: ActionSignOut})
+		}
+	}
+	result.Sort()
+	return result, err
+}
+
+func (o Odoo) fetchAttendances(ctx context.Context, domainFilters domain.Domain) (AttendanceList, error) {
+	// attendances over multiple years can be a lot of records, page through them.
+	list, err := odoo.SearchAll[Attendance](ctx, o.querier, odoo.SearchReadModel{
 		Model:  "hr.attendance",
 		Domain: domainFilters,
 		Fields: []string{"employee_id", "name", "action", "action_desc"},
-		Limit:  0,
-		Offset: 0,
-	}, &result)
+	}, odoo.DefaultPageSize)
+	result := AttendanceList(list)
 	result.Sort()
 	return result, err
 }
 
+// attendanceWrite contains the fields of an "hr.attendance" record of Odoo 8 that can be written.
+type attendanceWrite struct {
+	EmployeeID int         `json:"employee_id,omitempty"`
+	DateTime   *odoo.Date  `json:"name,omitempty"`
+	Action     string      `json:"action,omitempty"`
+	Reason     interface{} `json:"action_desc"`
+}
+
+// attendanceShiftWrite contains the fields of an "hr.attendance" record of Odoo 10 and later that can be written.
+// Unset fields are not written, use odoo.Date{} to clear a field.
+type attendanceShiftWrite struct {
+	EmployeeID int        `json:"employee_id,omitempty"`
+	CheckIn    *odoo.Date `json:"check_in,omitempty"`
+	CheckOut   *odoo.Date `json:"check_out,omitempty"`
+}
+
+// CreateAttendance creates a new attendance for the given employee and returns its ID.
+// In Odoo 10 and later, a sign_in starts a new shift, while a sign_out closes the latest shift that is still open at the given time.
+// Reasons aren't supported there and are ignored.
+func (o Odoo) CreateAttendance(ctx context.Context, employeeID int, attendance Attendance) (int, error) {
+	utc := odoo.Date{Time: attendance.DateTime.UTC()}
+	if !o.adapter.attendanceShifts {
+		return o.querier.CreateGenericModel(ctx, "hr.attendance", attendanceWrite{
+			EmployeeID: employeeID,
+			DateTime:   &utc,
+			Action:     attendance.Action,
+			Reason:     attendance.Reason.WriteValue(),
+		})
+	}
+	if attendance.Action == ActionSignIn {
+		return o.querier.CreateGenericModel(ctx, "hr.attendance", attendanceShiftWrite{EmployeeID: employeeID, CheckIn: &utc})
+	}
+	open := odoo.List[attendanceShift]{}
+	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
+		Model: "hr.attendance",
+		Domain: domain.New(
+			domain.Eq("employee_id", employeeID),
+			domain.Eq("check_out", false),
+			domain.Lte("check_in", utc.Format(odoo.DateTimeFormat)),
+		),
+		Fields: []string{"check_in", "check_out"},
+		Sort:   "check_in DESC",
+		Limit:  1,
+	}, &open)
+	if err != nil {
+		return 0, err
+	}
+	if len(open.Items) == 0 {
+		return 0, fmt.Errorf("cannot add %s at %s: no open shift found", ActionSignOut, utc.Format(odoo.DateTimeFormat))
+	}
+	id := open.Items[0].ID
+	return id, o.querier.UpdateGenericModel(ctx, "hr.attendance", id, attendanceShiftWrite{CheckOut: &utc})
+}
+
+// UpdateAttendance updates the time and reason of the given attendance.
+// The action of an existing attendance can't be changed.
+// In Odoo 10 and later, the check-in or check-out of the shift is updated depending on the action, reasons are ignored.
+func (o Odoo) UpdateAttendance(ctx context.Context, attendance Attendance) error {
+	utc := odoo.Date{Time: attendance.DateTime.UTC()}
+	if !o.adapter.attendanceShifts {
+		return o.querier.UpdateGenericModel(ctx, "hr.attendance", attendance.ID, attendanceWrite{
+			DateTime: &utc,
+			Reason:   attendance.Reason.WriteValue(),
+		})
+	}
+	if attendance.Action == ActionSignIn {
+		return o.querier.UpdateGenericModel(ctx, "hr.attendance", attendance.ID, attendanceShiftWrite{CheckIn: &utc})
+	}
+	return o.querier.UpdateGenericModel(ctx, "hr.attendance", attendance.ID, attendanceShiftWrite{CheckOut: &utc})
+}
+
+// DeleteAttendance deletes the given attendance.
+// In Odoo 10 and later, deleting a sign_in deletes the whole shift, while deleting a sign_out reopens the shift.
+func (o Odoo) DeleteAttendance(ctx context.Context, attendance Attendance) error {
+	if o.adapter.attendanceShifts && attendance.Action == ActionSignOut {
+		return o.querier.UpdateGenericModel(ctx, "hr.attendance", attendance.ID, attendanceShiftWrite{CheckOut: &odoo.Date{}})
+	}
+	return o.querier.DeleteGenericModel(ctx, "hr.attendance", []int{attendance.ID})
+}
+
 // Sort sorts the attendances by date ascending (oldest first).
 // Dates are compared by Unix time (ignoring nanoseconds).
 // If two attendances have the same date then they're sorted by Attendance.Action.
@@ -1,12 +1,15 @@
 package model
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/odootest"
 )
 
 func TestAttendanceList_Sort(t *testing.T) {
@@ -174,3 +177,39 @@ func TestAttendanceList_FilterAttendanceBetweenDates(t *testing.T) {
 		})
 	}
 }
+
+func TestOdoo_CreateUpdateDeleteAttendance(t *testing.T) {
+	server := odootest.NewServer(odootest.DefaultFixtures())
+	defer server.Close()
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	o := NewOdoo(session)
+	ctx := context.Background()
+
+	id, err := o.CreateAttendance(ctx, 2, Attendance{
+		DateTime: odoo.NewDate(2022, time.March, 3, 18, 0, 0, zurichTZ),
+		Action:   ActionSignOut,
+		Reason:   &ActionReason{ID: 2, Name: "Outside office hours"},
+	})
+	require.NoError(t, err)
+	created, found := server.Record("hr.attendance", id)
+	require.True(t, found)
+	assert.Equal(t, "2022-03-03 17:00:00", created["name"], "should be saved in UTC")
+	assert.Equal(t, ActionSignOut, created["action"])
+	assert.Equal(t, 2.0, created["action_desc"])
+	assert.Equal(t, 2.0, created["employee_id"])
+
+	err = o.UpdateAttendance(ctx, Attendance{ID: id, DateTime: odoo.NewDate(2022, time.March, 3, 19, 0, 0, zurichTZ), Action: ActionSignOut})
+	require.NoError(t, err)
+	updated, _ := server.Record("hr.attendance", id)
+	assert.Equal(t, "2022-03-03 18:00:00", updated["name"])
+	assert.Equal(t, false, updated["action_desc"])
+	assert.Equal(t, ActionSignOut, updated["action"])
+
+	err = o.DeleteAttendance(ctx, Attendance{ID: id, Action: ActionSignOut})
+	require.NoError(t, err)
+	_, found = server.Record("hr.attendance", id)
+	assert.False(t, found)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 type Contract struct {
@@ -89,17 +90,34 @@ func (l ContractList) GetEarliestStartContractDate() time.Time {
 }
 
 func (o Odoo) FetchAllContractsOfEmployee(ctx context.Context, employeeID int) (ContractList, error) {
-	return o.readContracts(ctx, []odoo.Filter{
-		[]interface{}{"employee_id", "=", employeeID},
-	})
+	return o.readContracts(ctx, domain.New(
+		domain.Eq("employee_id", employeeID),
+	))
+}
+
+// calendarContract is an "hr.contract" record of Odoo 11 and later, where the working schedule is in "resource_calendar_id".
+type calendarContract struct {
+	Contract
+	Calendar *WorkingSchedule `json:"resource_calendar_id"`
 }
 
-func (o Odoo) readContracts(ctx context.Context, domainFilters []odoo.Filter) (ContractList, error) {
-	result := ContractList{}
-	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
+func (o Odoo) readContracts(ctx context.Context, domainFilters domain.Domain) (ContractList, error) {
+	query := odoo.SearchReadModel{
 		Model:  "hr.contract",
 		Domain: domainFilters,
-		Fields: []string{"date_start", "date_end", "working_hours"},
-	}, &result)
+		Fields: []string{"date_start", "date_end", o.adapter.contractScheduleField},
+	}
+	if o.adapter.contractScheduleField == "working_hours" {
+		result := ContractList{}
+		err := o.querier.SearchGenericModel(ctx, query, &result)
+		return result, err
+	}
+	contracts := odoo.List[calendarContract]{}
+	err := o.querier.SearchGenericModel(ctx, query, &contracts)
+	result := ContractList{Items: make([]Contract, len(contracts.Items))}
+	for i, contract := range contracts.Items {
+		result.Items[i] = contract.Contract
+		result.Items[i].WorkingSchedule = contract.Calendar
+	}
 	return result, err
 }
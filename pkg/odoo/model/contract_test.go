@@ -12,10 +12,10 @@ import (
 )
 
 func newFTESchedule(ratioPercentage int) *WorkingSchedule {
-	return &WorkingSchedule{
+	return &WorkingSchedule{Many2One: odoo.Many2One{
 		ID:   0,
 		Name: strconv.Itoa(ratioPercentage) + "%",
-	}
+	}}
 }
 
 func TestContractList_GetFTERatioForDay(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 type Employee struct {
@@ -15,22 +16,31 @@ type Employee struct {
 // If multiple employees are found, the first is returned.
 // Returns nil if none found.
 func (o Odoo) SearchEmployee(ctx context.Context, searchString string) (*Employee, error) {
-	return o.readEmployee(ctx, []odoo.Filter{[]string{"name", "ilike", searchString}})
+	return o.readEmployee(ctx, domain.New(domain.ILike("name", searchString)))
 }
 
 // FetchEmployeeByID fetches an Employee for the given employee ID.
 // Returns nil if not found.
 func (o Odoo) FetchEmployeeByID(ctx context.Context, employeeID int) (*Employee, error) {
-	return o.readEmployee(ctx, []odoo.Filter{[]interface{}{"resource_id", "=", employeeID}})
+	return o.readEmployee(ctx, domain.New(domain.Eq("resource_id", employeeID)))
 }
 
 // FetchEmployeeByUserID fetches the Employee for the given user ID (which might not be the same as Employee.ID.
 // Returns nil if not found.
 func (o Odoo) FetchEmployeeByUserID(ctx context.Context, userID int) (*Employee, error) {
-	return o.readEmployee(ctx, []odoo.Filter{[]interface{}{"user_id", "=", userID}})
+	return o.readEmployee(ctx, domain.New(domain.Eq("user_id", userID)))
 }
 
-func (o Odoo) readEmployee(ctx context.Context, filters []odoo.Filter) (*Employee, error) {
+// FetchAllEmployees fetches all employees with a VSHN email address.
+func (o Odoo) FetchAllEmployees(ctx context.Context) (odoo.List[Employee], error) {
+	return odoo.SearchAll[Employee](ctx, o.querier, odoo.SearchReadModel{
+		Model:  "hr.employee",
+		Domain: domain.New(domain.ILike("work_email", "@vshn.ch")),
+		Fields: []string{"name"},
+	}, odoo.DefaultPageSize)
+}
+
+func (o Odoo) readEmployee(ctx context.Context, filters domain.Domain) (*Employee, error) {
 	result := odoo.List[Employee]{}
 	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
 		Model:  "hr.employee",
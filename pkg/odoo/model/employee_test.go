@@ -0,0 +1,28 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/odootest"
+)
+
+func TestOdoo_FetchAllEmployees(t *testing.T) {
+	fixtures := odootest.DefaultFixtures()
+	fixtures.Models["hr.employee"] = append(fixtures.Models["hr.employee"],
+		odootest.Record{"id": 3, "name": "John Doe", "user_id": false, "resource_id": []interface{}{3, "John Doe"}, "work_email": "john.doe@example.com"},
+	)
+	server := odootest.NewServer(fixtures)
+	defer server.Close()
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+
+	employees, err := NewOdoo(session).FetchAllEmployees(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Employee{{ID: 2, Name: "Jane Doe"}}, employees.Items, "employees without VSHN email shouldn't be returned")
+}
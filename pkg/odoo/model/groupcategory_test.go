@@ -14,7 +14,7 @@ func TestGroupCategory_MarshalJSON(t *testing.T) {
 	}{
 		"GivenInput_WhenEmpty_ThenReturnFalse": {
 			givenInput:     GroupCategory{},
-			expectedOutput: `[0,""]`,
+			expectedOutput: `false`,
 		},
 		"GivenInput_WhenInputArray_ThenReturnString": {
 			givenInput: GroupCategory{
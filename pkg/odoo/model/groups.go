@@ -4,20 +4,21 @@ import (
 	"context"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 // Group contains a list of users.
 type Group struct {
-	Name     string        `json:"name"`
-	Category GroupCategory `json:"category_id"`
-	UserIDs  []int         `json:"users"`
+	Name     string         `json:"name"`
+	Category GroupCategory  `json:"category_id"`
+	UserIDs  odoo.Many2Many `json:"users"`
 }
 
 func (o Odoo) FetchGroupByName(ctx context.Context, category, name string) (*Group, error) {
-	groups, err := o.searchGroups(ctx, []odoo.Filter{
-		[]string{"name", "=", name},
-		[]interface{}{"category_id.name", "=", category},
-	})
+	groups, err := o.searchGroups(ctx, domain.New(
+		domain.Eq("name", name),
+		domain.Eq("category_id.name", category),
+	))
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +28,7 @@ func (o Odoo) FetchGroupByName(ctx context.Context, category, name string) (*Gro
 	return nil, nil
 }
 
-func (o Odoo) searchGroups(ctx context.Context, domainFilters []odoo.Filter) (odoo.List[Group], error) {
+func (o Odoo) searchGroups(ctx context.Context, domainFilters domain.Domain) (odoo.List[Group], error) {
 	result := odoo.List[Group]{}
 	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
 		Model:  "res.groups",
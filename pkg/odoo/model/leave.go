@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 type Leave struct {
@@ -33,27 +34,40 @@ type Leave struct {
 func (o Odoo) FetchLeavesBetweenDates(ctx context.Context, employeeID int, begin, end time.Time) (odoo.List[Leave], error) {
 	beginStr := begin.Format(odoo.DateFormat)
 	endStr := end.Format(odoo.DateFormat)
-	return o.readLeaves(ctx, []odoo.Filter{
-		[]string{"type", "=", "remove"}, // Only return used leaves. With type = "add" we would get leaves that add days to holiday budget
-		[]interface{}{"employee_id", "=", employeeID},
-		"|",
-		"|",
-		"&",
-		[]string{"date_from", ">=", beginStr},
-		[]string{"date_from", "<=", endStr},
-		"&",
-		[]string{"date_from", "<=", beginStr},
-		[]string{"date_to", ">=", beginStr},
-		"&",
-		[]string{"date_from", "<=", endStr},
-		[]string{"date_to", ">=", beginStr},
-	})
+	filters := domain.New(
+		domain.Eq("employee_id", employeeID),
+		domain.Or(
+			domain.And(domain.Gte("date_from", beginStr), domain.Lte("date_from", endStr)),
+			domain.And(domain.Lte("date_from", beginStr), domain.Gte("date_to", beginStr)),
+			domain.And(domain.Lte("date_from", endStr), domain.Gte("date_to", beginStr)),
+		),
+	)
+	if o.adapter.hasLeaveTypes() {
+		// Only return used leaves. With type = "add" we would get leaves that add days to holiday budget
+		filters = append(domain.New(domain.Eq("type", "remove")), filters...)
+	}
+	return o.readLeaves(ctx, filters)
+}
+
+// FetchLeavesOfTypes returns the leaves of the given employee that have one of the given leave types, regardless of their dates.
+func (o Odoo) FetchLeavesOfTypes(ctx context.Context, employeeID int, typeIDs []int) (odoo.List[Leave], error) {
+	if len(typeIDs) == 0 {
+		return odoo.List[Leave]{}, nil
+	}
+	filters := domain.New(
+		domain.Eq("employee_id", employeeID),
+		domain.In("holiday_status_id", typeIDs...),
+	)
+	if o.adapter.hasLeaveTypes() {
+		filters = append(domain.New(domain.Eq("type", "remove")), filters...)
+	}
+	return o.readLeaves(ctx, filters)
 }
 
-func (o Odoo) readLeaves(ctx context.Context, domainFilters []odoo.Filter) (odoo.List[Leave], error) {
+func (o Odoo) readLeaves(ctx context.Context, domainFilters domain.Domain) (odoo.List[Leave], error) {
 	result := odoo.List[Leave]{}
 	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
-		Model:  "hr.holidays",
+		Model:  o.adapter.leaveModel,
 		Domain: domainFilters,
 		Fields: []string{"date_from", "date_to", "holiday_status_id", "state"},
 		Limit:  0,
@@ -62,26 +76,34 @@ func (o Odoo) readLeaves(ctx context.Context, domainFilters []odoo.Filter) (odoo
 	return result, err
 }
 
-// SplitByDay splits the Leave into multiple leaves separated by day.
+// SplitByDay splits the Leave into multiple leaves separated by day in the location of DateFrom.
 // The given Leave can span multiple days, but with arbitrary start and end times.
-// This function also normalizes the leaves, so that each Leave spans a full day, from midnight to 23:59:59.
+// The leaves keep the start and end times within their day, so that e.g. a half-day leave doesn't span the full day.
+// Days in between span from midnight to 23:59:59.
 func (l Leave) SplitByDay() []Leave {
 	arr := make([]Leave, 0)
-	start := l.DateFrom
-	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
-	end := l.DateTo
-	endDate := time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 0, end.Location())
-	for currentDate := startDate; currentDate.Before(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
-		from := odoo.Date{Time: currentDate}
-		to := odoo.Date{Time: currentDate.AddDate(0, 0, 1).Add(-1 * time.Second)}
+	tz := l.DateFrom.Location()
+	end := l.DateTo.In(tz)
+	for from := l.DateFrom.Time; from.Before(end); {
+		nextDay := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, tz)
+		to := nextDay.Add(-1 * time.Second)
+		if end.Before(to) {
+			to = end
+		}
 		newLeave := Leave{
-			DateFrom: from,
-			DateTo:   to,
+			DateFrom: odoo.Date{Time: from},
+			DateTo:   odoo.Date{Time: to},
 			Type:     l.Type,
 			State:    l.State,
 			ID:       l.ID, // using the same leave will cause problems when saving, if this is used.
 		}
 		arr = append(arr, newLeave)
+		from = nextDay
 	}
 	return arr
 }
+
+// Duration returns the difference between DateFrom and DateTo.
+func (l Leave) Duration() time.Duration {
+	return l.DateTo.Sub(l.DateFrom.Time)
+}
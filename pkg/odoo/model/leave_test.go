@@ -10,7 +10,7 @@ import (
 )
 
 func TestLeave_SplitByDay(t *testing.T) {
-	t.Run("GivenLeaveWithSingleDate_ThenExpectSameLeave", func(t *testing.T) {
+	t.Run("GivenLeaveWithinSingleDay_ThenExpectSameLeave", func(t *testing.T) {
 		givenLeave := Leave{
 			ID:       1,
 			DateFrom: odoo.MustParseDateTime("2021-02-03 07:00:00"),
@@ -20,8 +20,8 @@ func TestLeave_SplitByDay(t *testing.T) {
 		}
 		expectedLeave := Leave{
 			ID:       1,
-			DateFrom: odoo.NewDate(2021, 02, 03, 0, 0, 0, time.UTC),
-			DateTo:   odoo.NewDate(2021, 02, 03, 23, 59, 59, time.UTC),
+			DateFrom: odoo.NewDate(2021, 02, 03, 7, 0, 0, time.UTC),
+			DateTo:   odoo.NewDate(2021, 02, 03, 19, 0, 0, time.UTC),
 			Type:     &LeaveType{ID: 1, Name: "SomeType"},
 			State:    "validated",
 		}
@@ -39,8 +39,46 @@ func TestLeave_SplitByDay(t *testing.T) {
 				DateFrom: odoo.MustParseDateTime("2021-02-03 07:00:00"), DateTo: odoo.MustParseDateTime("2021-02-04 19:00:00"),
 			},
 			expectedLeaves: []Leave{
-				{DateFrom: odoo.MustParseDateTime("2021-02-03 00:00:00"), DateTo: odoo.MustParseDateTime("2021-02-03 23:59:59")},
+				{DateFrom: odoo.MustParseDateTime("2021-02-03 07:00:00"), DateTo: odoo.MustParseDateTime("2021-02-03 23:59:59")},
+				{DateFrom: odoo.MustParseDateTime("2021-02-04 00:00:00"), DateTo: odoo.MustParseDateTime("2021-02-04 19:00:00")},
+			},
+		},
+		"GivenLeave_WhenSpanningMultipleDays_ThenExpectFullDaysInBetween": {
+			givenLeave: Leave{
+				DateFrom: odoo.MustParseDateTime("2021-02-03 12:00:00"), DateTo: odoo.MustParseDateTime("2021-02-05 12:00:00"),
+			},
+			expectedLeaves: []Leave{
+				{DateFrom: odoo.MustParseDateTime("2021-02-03 12:00:00"), DateTo: odoo.MustParseDateTime("2021-02-03 23:59:59")},
 				{DateFrom: odoo.MustParseDateTime("2021-02-04 00:00:00"), DateTo: odoo.MustParseDateTime("2021-02-04 23:59:59")},
+				{DateFrom: odoo.MustParseDateTime("2021-02-05 00:00:00"), DateTo: odoo.MustParseDateTime("2021-02-05 12:00:00")},
+			},
+		},
+		"GivenLeave_WhenEndingAtMidnight_ThenDontAddEmptyDay": {
+			givenLeave: Leave{
+				DateFrom: odoo.MustParseDateTime("2021-02-03 00:00:00"), DateTo: odoo.MustParseDateTime("2021-02-04 00:00:00"),
+			},
+			expectedLeaves: []Leave{
+				{DateFrom: odoo.MustParseDateTime("2021-02-03 00:00:00"), DateTo: odoo.MustParseDateTime("2021-02-03 23:59:59")},
+			},
+		},
+		"GivenLeaveInUTC_WhenLocalizedToZurich_ThenSplitAtLocalMidnight": {
+			givenLeave: Leave{
+				DateFrom: odoo.Date{Time: odoo.MustParseDateTime("2021-02-02 23:00:00").In(zurichTZ)},
+				DateTo:   odoo.Date{Time: odoo.MustParseDateTime("2021-02-03 22:59:59").In(zurichTZ)},
+			},
+			expectedLeaves: []Leave{
+				{DateFrom: odoo.NewDate(2021, 02, 03, 0, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 02, 03, 23, 59, 59, zurichTZ)},
+			},
+		},
+		"GivenLeave_WhenSpanningDaylightSavingTimeSwitch_ThenSplitAtLocalMidnight": {
+			// 2021-03-28 has only 23 hours in Zurich
+			givenLeave: Leave{
+				DateFrom: odoo.NewDate(2021, 03, 27, 12, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 03, 29, 12, 0, 0, zurichTZ),
+			},
+			expectedLeaves: []Leave{
+				{DateFrom: odoo.NewDate(2021, 03, 27, 12, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 03, 27, 23, 59, 59, zurichTZ)},
+				{DateFrom: odoo.NewDate(2021, 03, 28, 0, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 03, 28, 23, 59, 59, zurichTZ)},
+				{DateFrom: odoo.NewDate(2021, 03, 29, 0, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 03, 29, 12, 0, 0, zurichTZ)},
 			},
 		},
 	}
@@ -51,11 +89,16 @@ func TestLeave_SplitByDay(t *testing.T) {
 			for i := 0; i < len(result); i++ {
 				actual := result[i]
 				expected := tt.expectedLeaves[i]
-				assert.Equal(t, expected.DateFrom, actual.DateFrom)
-				assert.Equal(t, expected.DateTo, actual.DateTo)
+				assert.True(t, expected.DateFrom.Equal(actual.DateFrom.Time), "from: expected %s, got %s", expected.DateFrom, actual.DateFrom)
+				assert.True(t, expected.DateTo.Equal(actual.DateTo.Time), "to: expected %s, got %s", expected.DateTo, actual.DateTo)
 				assert.Equal(t, expected.State, actual.State)
 				assert.Zero(t, actual.ID)
 			}
 		})
 	}
 }
+
+func TestLeave_Duration(t *testing.T) {
+	leave := Leave{DateFrom: odoo.NewDate(2021, 03, 28, 0, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 03, 29, 0, 0, 0, zurichTZ)}
+	assert.Equal(t, 23*time.Hour, leave.Duration(), "daylight saving time switch")
+}
@@ -5,11 +5,19 @@ import "github.com/vshn/odootools/pkg/odoo"
 // Odoo is the developer-friendly odoo.Client with strongly-typed models.
 type Odoo struct {
 	querier odoo.QueryExecutor
+	adapter adapter
 }
 
-// NewOdoo creates a new Odoo client.
+// NewOdoo creates a new Odoo client for Odoo 8.
 func NewOdoo(querier odoo.QueryExecutor) *Odoo {
+	return NewOdooForVersion(querier, odoo.ServerVersion{})
+}
+
+// NewOdooForVersion creates a new Odoo client that queries the models of the given Odoo version, see odoo.Client.FetchVersion.
+// The returned models have the same shape for all versions.
+func NewOdooForVersion(querier odoo.QueryExecutor, version odoo.ServerVersion) *Odoo {
 	return &Odoo{
 		querier: querier,
+		adapter: newAdapter(version),
 	}
 }
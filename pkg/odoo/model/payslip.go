@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 type Payslip struct {
@@ -42,12 +43,12 @@ func (l PayslipList) Len() int {
 }
 
 func (o Odoo) FetchPayslipInMonth(ctx context.Context, employeeID int, firstDayOfMonth time.Time) (*Payslip, error) {
-	payslips, err := o.readPayslips(ctx, []odoo.Filter{
-		[]interface{}{"employee_id", "=", employeeID},
-		[]string{"date_from", ">=", firstDayOfMonth.AddDate(0, 0, -1).Format(odoo.DateFormat)},
-		[]string{"date_to", "<=", firstDayOfMonth.AddDate(0, 1, -1).Format(odoo.DateFormat)},
-		[]string{"name", "ilike", "Salary Slip"},
-	})
+	payslips, err := o.readPayslips(ctx, domain.New(
+		domain.Eq("employee_id", employeeID),
+		domain.Gte("date_from", firstDayOfMonth.AddDate(0, 0, -1).Format(odoo.DateFormat)),
+		domain.Lte("date_to", firstDayOfMonth.AddDate(0, 1, -1).Format(odoo.DateFormat)),
+		domain.ILike("name", "Salary Slip"),
+	))
 	if payslips.Len() > 0 {
 		return &payslips.Items[0], err
 	}
@@ -58,12 +59,12 @@ func (o Odoo) FetchPayslipInMonth(ctx context.Context, employeeID int, firstDayO
 // It may return empty list if none found.
 // If multiple found, they are sorted ascending by to their Payslip.DateFrom (earliest first).
 func (o Odoo) FetchPayslipBetween(ctx context.Context, employeeID int, firstDay, lastDay time.Time) (PayslipList, error) {
-	payslips, err := o.readPayslips(ctx, []odoo.Filter{
-		[]interface{}{"employee_id", "=", employeeID},
-		[]string{"date_from", ">=", firstDay.AddDate(0, 0, -1).Format(odoo.DateFormat)},
-		[]string{"date_to", "<=", lastDay.Format(odoo.DateFormat)},
-		[]string{"name", "ilike", "Salary Slip"},
-	})
+	payslips, err := o.readPayslips(ctx, domain.New(
+		domain.Eq("employee_id", employeeID),
+		domain.Gte("date_from", firstDay.AddDate(0, 0, -1).Format(odoo.DateFormat)),
+		domain.Lte("date_to", lastDay.Format(odoo.DateFormat)),
+		domain.ILike("name", "Salary Slip"),
+	))
 	// sort by start date ascending
 	sort.SliceStable(payslips.Items, func(i, j int) bool {
 		fromFirst := payslips.Items[i].DateFrom.Time
@@ -78,7 +79,7 @@ func (o Odoo) UpdatePayslip(ctx context.Context, payslip *Payslip) error {
 	return err
 }
 
-func (o Odoo) readPayslips(ctx context.Context, domainFilters []odoo.Filter) (PayslipList, error) {
+func (o Odoo) readPayslips(ctx context.Context, domainFilters domain.Domain) (PayslipList, error) {
 	result := PayslipList{}
 	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
 		Model:  "hr.payslip",
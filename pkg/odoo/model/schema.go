@@ -0,0 +1,80 @@
+package model
+
+import "github.com/vshn/odootools/pkg/odoo"
+
+// Schema returns the models and fields that odootools reads, writes or filters by.
+// It's meant to verify that an Odoo instance is compatible with odootools, see odoo.VerifySchema.
+// The models and fields depend on the Odoo version, see odoo.Client.FetchVersion.
+// Keep it in sync when adding fields to the queries of this package.
+func Schema(version odoo.ServerVersion) []odoo.ModelSchema {
+	a := newAdapter(version)
+	schemas := make([]odoo.ModelSchema, 0)
+	attendance := odoo.ModelSchema{Model: "hr.attendance", Fields: []odoo.FieldSpec{
+		odoo.NewFieldSpec("employee_id", "many2one"),
+		odoo.NewFieldSpec("check_in", "datetime"),
+		odoo.NewFieldSpec("check_out", "datetime"),
+	}}
+	if !a.attendanceShifts {
+		attendance.Fields = []odoo.FieldSpec{
+			odoo.NewFieldSpec("employee_id", "many2one"),
+			odoo.NewFieldSpec("name", "datetime"),
+			odoo.NewFieldSpec("action", "selection"),
+			odoo.NewFieldSpec("action_desc", "many2one"),
+		}
+		schemas = append(schemas, odoo.ModelSchema{Model: "hr.action.reason", Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("name", "char"),
+			odoo.NewFieldSpec("action_type", "selection"),
+		}})
+	}
+	leave := odoo.ModelSchema{Model: a.leaveModel, Fields: []odoo.FieldSpec{
+		odoo.NewFieldSpec("employee_id", "many2one"),
+		odoo.NewFieldSpec("date_from", "datetime"),
+		odoo.NewFieldSpec("date_to", "datetime"),
+		odoo.NewFieldSpec("holiday_status_id", "many2one"),
+		odoo.NewFieldSpec("state", "selection"),
+	}}
+	if a.hasLeaveTypes() {
+		leave.Fields = append(leave.Fields, odoo.NewFieldSpec("type", "selection"), odoo.NewFieldSpec(a.allocationDaysField, "float"))
+	} else {
+		schemas = append(schemas, odoo.ModelSchema{Model: a.allocationModel, Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("employee_id", "many2one"),
+			odoo.NewFieldSpec("holiday_status_id", "many2one"),
+			odoo.NewFieldSpec("state", "selection"),
+			odoo.NewFieldSpec(a.allocationDaysField, "float"),
+		}})
+	}
+	return append(schemas, []odoo.ModelSchema{
+		attendance,
+		{Model: "hr.contract", Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("employee_id", "many2one"),
+			odoo.NewFieldSpec("date_start", "date"),
+			odoo.NewFieldSpec("date_end", "date"),
+			odoo.NewFieldSpec(a.contractScheduleField, "many2one"),
+		}},
+		{Model: "hr.employee", Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("name", "char"),
+			odoo.NewFieldSpec("resource_id", "many2one"),
+			odoo.NewFieldSpec("user_id", "many2one"),
+			odoo.NewFieldSpec("work_email", "char"),
+		}},
+		leave,
+		{Model: "hr.payslip", Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("employee_id", "many2one"),
+			odoo.NewFieldSpec("name", "char"),
+			odoo.NewFieldSpec("date_from", "date"),
+			odoo.NewFieldSpec("date_to", "date"),
+			odoo.NewFieldSpec("x_overtime", "char", "text"),
+			odoo.NewFieldSpec("x_timezone", "char", "selection"),
+		}},
+		{Model: "res.groups", Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("name", "char"),
+			odoo.NewFieldSpec("category_id", "many2one"),
+			odoo.NewFieldSpec("users", "many2many"),
+		}},
+		{Model: "res.users", Fields: []odoo.FieldSpec{
+			odoo.NewFieldSpec("name", "char"),
+			odoo.NewFieldSpec("tz", "selection"),
+			odoo.NewFieldSpec("email", "char"),
+		}},
+	}...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
 )
 
 type User struct {
@@ -14,9 +15,9 @@ type User struct {
 }
 
 func (o Odoo) FetchUserByID(ctx context.Context, id int) (*User, error) {
-	users, err := o.readUser(ctx, []odoo.Filter{
-		[]interface{}{"id", "=", id},
-	})
+	users, err := o.readUser(ctx, domain.New(
+		domain.Eq("id", id),
+	))
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +27,7 @@ func (o Odoo) FetchUserByID(ctx context.Context, id int) (*User, error) {
 	return nil, nil
 }
 
-func (o Odoo) readUser(ctx context.Context, domainFilters []odoo.Filter) (odoo.List[User], error) {
+func (o Odoo) readUser(ctx context.Context, domainFilters domain.Domain) (odoo.List[User], error) {
 	result := odoo.List[User]{}
 	err := o.querier.SearchGenericModel(ctx, odoo.SearchReadModel{
 		Model:  "res.users",
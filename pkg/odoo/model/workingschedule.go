@@ -1,62 +1,29 @@
 package model
 
 import (
-	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/vshn/odootools/pkg/odoo"
 )
 
 // should match something like "Standard 100% Work Week"
 var workingScheduleRegex = regexp.MustCompile(`(?P<ratio>[0-9]+\s*%)`)
 
+// WorkingSchedule is the "working_hours" of a contract.
+// The FTE ratio is part of the name, e.g. `[1, "Standard 100% Work Week"]`.
 type WorkingSchedule struct {
-	ID   float64
-	Name string
-}
-
-// String implements fmt.Stringer.
-func (s *WorkingSchedule) String() string {
-	if s == nil {
-		return ""
-	}
-	return s.Name
-}
-
-// MarshalJSON implements json.Marshaler.
-func (s *WorkingSchedule) MarshalJSON() ([]byte, error) {
-	if s.Name == "" {
-		return []byte("false"), nil
-	}
-	arr := []interface{}{s.ID, s.Name}
-	return json.Marshal(arr)
-}
-
-// UnmarshalJSON implements json.Unmarshaler.
-func (s *WorkingSchedule) UnmarshalJSON(b []byte) error {
-	var f bool
-	if err := json.Unmarshal(b, &f); err == nil || string(b) == "false" {
-		return nil
-	}
-	var arr []interface{}
-	if err := json.Unmarshal(b, &arr); err != nil {
-		return err
-	}
-	if len(arr) >= 2 {
-		if v, ok := arr[1].(string); ok {
-			*s = WorkingSchedule{
-				ID:   arr[0].(float64),
-				Name: v,
-			}
-		}
-	}
-	return nil
+	odoo.Many2One
 }
 
 // GetFTERatio tries to extract the FTE ratio from the name of the schedule.
 // It returns an error if it could not find a match
 func (s *WorkingSchedule) GetFTERatio() (float64, error) {
+	if s == nil {
+		return 0, fmt.Errorf("no working schedule defined")
+	}
 	match := workingScheduleRegex.FindStringSubmatch(s.Name)
 	if len(match) > 0 {
 		v := match[0]
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
 )
 
 func TestWorkingSchedule_GetFTERatio(t *testing.T) {
@@ -36,9 +37,9 @@ func TestWorkingSchedule_GetFTERatio(t *testing.T) {
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			subject := WorkingSchedule{
+			subject := WorkingSchedule{Many2One: odoo.Many2One{
 				Name: tt.givenNamePattern,
-			}
+			}}
 			result, err := subject.GetFTERatio()
 			if tt.expectErr {
 				require.Error(t, err)
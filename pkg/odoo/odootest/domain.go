@@ -0,0 +1,225 @@
+package odootest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// predicate returns true if the given record matches.
+type predicate func(model string, record Record) bool
+
+// compileDomain parses a domain in Polish notation as sent by the client (already JSON-decoded).
+// Top-level criteria are combined with AND.
+func (s *Server) compileDomain(raw []interface{}) (predicate, error) {
+	predicates := make([]predicate, 0)
+	rest := raw
+	for len(rest) > 0 {
+		p, remaining, err := s.parseCriterion(rest)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+		rest = remaining
+	}
+	return func(model string, record Record) bool {
+		for _, p := range predicates {
+			if !p(model, record) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func (s *Server) parseCriterion(tokens []interface{}) (predicate, []interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("unexpected end of domain")
+	}
+	switch token := tokens[0].(type) {
+	case string:
+		switch token {
+		case "!":
+			p, rest, err := s.parseCriterion(tokens[1:])
+			if err != nil {
+				return nil, nil, err
+			}
+			return func(model string, record Record) bool { return !p(model, record) }, rest, nil
+		case "&", "|":
+			left, rest, err := s.parseCriterion(tokens[1:])
+			if err != nil {
+				return nil, nil, err
+			}
+			right, rest, err := s.parseCriterion(rest)
+			if err != nil {
+				return nil, nil, err
+			}
+			if token == "&" {
+				return func(model string, record Record) bool { return left(model, record) && right(model, record) }, rest, nil
+			}
+			return func(model string, record Record) bool { return left(model, record) || right(model, record) }, rest, nil
+		}
+		return nil, nil, fmt.Errorf("unknown domain operator: %q", token)
+	case []interface{}:
+		p, err := s.parseTerm(token)
+		return p, tokens[1:], err
+	}
+	return nil, nil, fmt.Errorf("unexpected domain element: %v", tokens[0])
+}
+
+func (s *Server) parseTerm(term []interface{}) (predicate, error) {
+	if len(term) != 3 {
+		return nil, fmt.Errorf("domain term needs exactly 3 elements: %v", term)
+	}
+	field, ok := term[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("field of domain term is not a string: %v", term)
+	}
+	operator, ok := term[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("operator of domain term is not a string: %v", term)
+	}
+	expected := term[2]
+	var compare func(actual interface{}) bool
+	switch operator {
+	case "=":
+		compare = func(actual interface{}) bool { return equals(actual, expected) }
+	case "!=":
+		compare = func(actual interface{}) bool { return !equals(actual, expected) }
+	case "ilike":
+		compare = func(actual interface{}) bool {
+			return strings.Contains(strings.ToLower(toString(actual)), strings.ToLower(toString(expected)))
+		}
+	case ">=", "<=", ">", "<":
+		compare = func(actual interface{}) bool { return compareOrdered(actual, expected, operator) }
+	case "in":
+		values, ok := expected.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("value of 'in' term is not a list: %v", term)
+		}
+		compare = func(actual interface{}) bool {
+			for _, v := range values {
+				if equals(actual, v) {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		return nil, fmt.Errorf("unsupported domain operator: %q", operator)
+	}
+	return func(model string, record Record) bool {
+		return compare(s.resolvePath(model, record, strings.Split(field, ".")))
+	}, nil
+}
+
+// resolvePath returns the value of a (dotted) field path.
+func (s *Server) resolvePath(model string, record Record, path []string) interface{} {
+	value, exists := record[path[0]]
+	if !exists {
+		value = false
+	}
+	if len(path) == 1 {
+		return value
+	}
+	if related, hasRelation := s.relations[model+"."+path[0]]; hasRelation {
+		id := toID(value)
+		if relatedRecord, found := s.records[related][id]; found {
+			return s.resolvePath(related, relatedRecord, path[1:])
+		}
+		return false
+	}
+	// fall back to the many2one value [id, "name"]
+	if arr, isArr := value.([]interface{}); isArr && len(arr) == 2 && len(path) == 2 {
+		switch path[1] {
+		case "id":
+			return arr[0]
+		case "name":
+			return arr[1]
+		}
+	}
+	return false
+}
+
+// equals compares the values, whereas many2one values `[id, "name"]` are compared by their ID and many2many lists match if they contain the value.
+func equals(actual, expected interface{}) bool {
+	if arr, isArr := actual.([]interface{}); isArr {
+		if len(arr) == 2 {
+			if _, isName := arr[1].(string); isName {
+				return equals(arr[0], expected)
+			}
+		}
+		for _, v := range arr {
+			if equals(v, expected) {
+				return true
+			}
+		}
+		return false
+	}
+	if a, isNum := toFloat(actual); isNum {
+		if e, isNum := toFloat(expected); isNum {
+			return a == e
+		}
+	}
+	return actual == expected
+}
+
+func compareOrdered(actual, expected interface{}, operator string) bool {
+	if actual == false || actual == nil {
+		return false
+	}
+	var cmp int
+	a, aIsNum := toFloat(actual)
+	e, eIsNum := toFloat(expected)
+	if aIsNum && eIsNum {
+		switch {
+		case a < e:
+			cmp = -1
+		case a > e:
+			cmp = 1
+		}
+	} else {
+		// dates are compared as strings, which works for the "2006-01-02 15:04:05" format.
+		cmp = strings.Compare(toString(actual), toString(expected))
+	}
+	switch operator {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp < 0
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func toID(v interface{}) int {
+	if arr, isArr := v.([]interface{}); isArr && len(arr) > 0 {
+		v = arr[0]
+	}
+	f, _ := toFloat(v)
+	return int(f)
+}
+
+func toString(v interface{}) string {
+	if v == nil || v == false {
+		return ""
+	}
+	if arr, isArr := v.([]interface{}); isArr && len(arr) == 2 {
+		v = arr[1]
+	}
+	if str, isStr := v.(string); isStr {
+		return str
+	}
+	return fmt.Sprintf("%v", v)
+}
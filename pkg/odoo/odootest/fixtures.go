@@ -0,0 +1,93 @@
+package odootest
+
+// Record is a single data record of a model in the fake Odoo.
+// Values are stored the way Odoo returns them in JSON, e.g. many2one fields as `[id, "name"]` and undefined fields as `false`.
+type Record map[string]interface{}
+
+// ID returns the "id" field of the record.
+func (r Record) ID() int {
+	switch v := r["id"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// Fixtures contains the initial data of a Server.
+type Fixtures struct {
+	// Database is the name of the database that clients need to log in to.
+	// Any name is accepted if empty.
+	Database string
+	// ServerVersion is the version returned by `/web/webclient/version_info`, e.g. "16.0".
+	// It doesn't change the models of the fake, the fixtures have to match the version.
+	// "8.0" is returned if empty.
+	ServerVersion string
+	// Models maps the model name (e.g. "hr.employee") to its records.
+	// Each record requires a unique "id" within the model.
+	//
+	// Users are authenticated against the "login" and "password" fields of the "res.users" records.
+	Models map[string][]Record
+	// Relations maps "<model>.<field>" to the name of the related model.
+	// It's used to resolve dotted paths like `employee_id.user_id` in domains.
+	// Without a relation, only `<field>.id` and `<field>.name` can be resolved from many2one values.
+	Relations map[string]string
+}
+
+// DefaultFixtures returns a small data set of a single employee in March 2022:
+//   - The user "jane" (password "secret") is linked to employee "Jane Doe" and is an HR manager.
+//   - Jane works full-time since 2021.
+//   - Jane worked on 2022-03-01 and 2022-03-02 and has an approved legal leave on 2022-03-03.
+//   - Jane has 25 days of "Legal Leaves 2022" allocated and requested another 2 days for 2022-04-11 and 2022-04-12, which aren't approved yet.
+//   - There are payslips for February 2022 (with overtime "10:00:00") and March 2022 (without overtime).
+func DefaultFixtures() Fixtures {
+	employee := []interface{}{2, "Jane Doe"}
+	return Fixtures{
+		Database: "TestDB",
+		Models: map[string][]Record{
+			"res.users": {
+				{"id": 1, "name": "Jane Doe", "login": "jane", "password": "secret", "tz": "Europe/Zurich", "email": "jane.doe@vshn.ch"},
+			},
+			"res.groups": {
+				{"id": 1, "name": "Manager", "category_id": []interface{}{19, "Human Resources"}, "users": []interface{}{1}},
+				{"id": 2, "name": "User", "category_id": []interface{}{19, "Human Resources"}, "users": []interface{}{1}},
+			},
+			"hr.employee": {
+				{"id": 2, "name": "Jane Doe", "user_id": []interface{}{1, "Jane Doe"}, "resource_id": []interface{}{2, "Jane Doe"}, "work_email": "jane.doe@vshn.ch"},
+			},
+			"hr.contract": {
+				{"id": 1, "employee_id": employee, "date_start": "2021-01-01", "date_end": false, "working_hours": []interface{}{1, "Standard 100% Work Week"}},
+			},
+			"hr.attendance": {
+				{"id": 1, "employee_id": employee, "name": "2022-03-01 07:00:00", "action": "sign_in", "action_desc": false},
+				{"id": 2, "employee_id": employee, "name": "2022-03-01 11:00:00", "action": "sign_out", "action_desc": false},
+				{"id": 3, "employee_id": employee, "name": "2022-03-01 12:00:00", "action": "sign_in", "action_desc": false},
+				{"id": 4, "employee_id": employee, "name": "2022-03-01 17:00:00", "action": "sign_out", "action_desc": false},
+				{"id": 5, "employee_id": employee, "name": "2022-03-02 07:00:00", "action": "sign_in", "action_desc": false},
+				{"id": 6, "employee_id": employee, "name": "2022-03-02 15:00:00", "action": "sign_out", "action_desc": false},
+			},
+			"hr.holidays": {
+				{"id": 1, "employee_id": employee, "type": "remove", "state": "validate", "holiday_status_id": []interface{}{16, "Legal Leaves 2022"},
+					"date_from": "2022-03-02 23:00:00", "date_to": "2022-03-03 22:59:59"},
+				{"id": 2, "employee_id": employee, "type": "add", "state": "validate", "holiday_status_id": []interface{}{16, "Legal Leaves 2022"},
+					"number_of_days_temp": 25.0, "date_from": false, "date_to": false},
+				{"id": 3, "employee_id": employee, "type": "remove", "state": "confirm", "holiday_status_id": []interface{}{16, "Legal Leaves 2022"},
+					"date_from": "2022-04-10 22:00:00", "date_to": "2022-04-12 21:59:59"},
+			},
+			"hr.payslip": {
+				{"id": 1, "employee_id": employee, "name": "Salary Slip of Jane Doe for February 2022", "date_from": "2022-02-01", "date_to": "2022-02-28",
+					"x_overtime": "10:00:00", "x_timezone": "Europe/Zurich"},
+				{"id": 2, "employee_id": employee, "name": "Salary Slip of Jane Doe for March 2022", "date_from": "2022-03-01", "date_to": "2022-03-31",
+					"x_overtime": false, "x_timezone": false},
+			},
+		},
+		Relations: map[string]string{
+			"hr.employee.user_id":       "res.users",
+			"hr.attendance.employee_id": "hr.employee",
+			"hr.holidays.employee_id":   "hr.employee",
+			"hr.contract.employee_id":   "hr.employee",
+			"hr.payslip.employee_id":    "hr.employee",
+		},
+	}
+}
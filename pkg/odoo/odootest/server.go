@@ -0,0 +1,401 @@
+// Package odootest provides an in-memory fake Odoo server for tests.
+//
+// The fake implements the JSON-RPC endpoints that odootools uses and evaluates the domain operators of the search queries.
+// It is not meant to replicate Odoo's business logic, records are stored and returned as given in the Fixtures.
+package odootest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/vshn/odootools/pkg/odoo"
+)
+
+// Server is a fake Odoo server.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	database  string
+	version   string
+	records   map[string]map[int]Record
+	relations map[string]string
+	sessions  map[string]int
+	nextID    int
+}
+
+// NewServer starts and returns a new Server with the given fixtures.
+// The caller should call Close when finished, to shut it down.
+func NewServer(fixtures Fixtures) *Server {
+	s := &Server{
+		database:  fixtures.Database,
+		version:   fixtures.ServerVersion,
+		records:   map[string]map[int]Record{},
+		relations: map[string]string{},
+		sessions:  map[string]int{},
+	}
+	for key, model := range fixtures.Relations {
+		s.relations[key] = model
+	}
+	if s.version == "" {
+		s.version = "8.0"
+	}
+	for model, records := range fixtures.Models {
+		s.records[model] = map[int]Record{}
+		for _, record := range records {
+			normalized := normalize(record)
+			id := normalized.ID()
+			s.records[model][id] = normalized
+			if id >= s.nextID {
+				s.nextID = id + 1
+			}
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/web/webclient/version_info", s.handleVersionInfo)
+	mux.HandleFunc("/web/session/authenticate", s.handleAuthenticate)
+	mux.HandleFunc("/web/dataset/search_read", s.withSession(s.handleSearchRead))
+	mux.HandleFunc("/web/dataset/call_kw/create", s.withSession(s.handleCreate))
+	mux.HandleFunc("/web/dataset/call_kw/write", s.withSession(s.handleWrite))
+	mux.HandleFunc("/web/dataset/call_kw/unlink", s.withSession(s.handleUnlink))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Records returns a copy of all records of the given model, sorted by ID.
+func (s *Server) Records(model string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedRecords(model)
+}
+
+// Record returns a copy of the record with the given ID.
+func (s *Server) Record(model string, id int) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, found := s.records[model][id]
+	if !found {
+		return nil, false
+	}
+	return copyRecord(record), true
+}
+
+// ExpireSessions invalidates all sessions, so that subsequent requests fail with a session-expired error.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = map[string]int{}
+}
+
+type rpcRequest struct {
+	ID     interface{}     `json:"id"`
+	Params json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	ID      interface{}        `json:"id"`
+	JSONRPC string             `json:"jsonrpc"`
+	Result  interface{}        `json:"result,omitempty"`
+	Error   *odoo.JSONRPCError `json:"error,omitempty"`
+}
+
+type handlerFunc func(params json.RawMessage) (interface{}, *odoo.JSONRPCError)
+
+func (s *Server) handleVersionInfo(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	version, err := odoo.ParseServerVersion(s.version)
+	if err != nil {
+		writeResponse(w, req, nil, newError(200, "Odoo Server Error", err.Error()))
+		return
+	}
+	writeResponse(w, req, map[string]interface{}{
+		"server_version":      version.Version,
+		"server_version_info": []interface{}{version.Major(), 0, 0, "final", 0, ""},
+		"protocol_version":    1,
+	}, nil)
+}
+
+func (s *Server) handleAuthenticate(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	params := odoo.LoginOptions{}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		writeResponse(w, req, nil, newError(200, "Odoo Server Error", err.Error()))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessionID := uuid.NewString()
+	if s.database != "" && params.DatabaseName != s.database {
+		writeResponse(w, req, nil, newError(200, "Odoo Server Error", fmt.Sprintf("database %q does not exist", params.DatabaseName)))
+		return
+	}
+	for _, user := range s.sortedRecords("res.users") {
+		if user["login"] == params.Username && user["password"] == params.Password && params.Password != "" {
+			s.sessions[sessionID] = user.ID()
+			writeResponse(w, req, map[string]interface{}{
+				"db":         params.DatabaseName,
+				"session_id": sessionID,
+				"uid":        user.ID(),
+				"username":   params.Username,
+			}, nil)
+			return
+		}
+	}
+	// Odoo returns "uid": false on wrong credentials
+	writeResponse(w, req, map[string]interface{}{
+		"db":         params.DatabaseName,
+		"session_id": sessionID,
+		"uid":        false,
+		"username":   params.Username,
+	}, nil)
+}
+
+func (s *Server) withSession(handler handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodeRequest(w, r)
+		if !ok {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		cookie, err := r.Cookie("session_id")
+		if err != nil {
+			writeResponse(w, req, nil, newSessionExpiredError())
+			return
+		}
+		if _, exists := s.sessions[cookie.Value]; !exists {
+			writeResponse(w, req, nil, newSessionExpiredError())
+			return
+		}
+		result, rpcErr := handler(req.Params)
+		writeResponse(w, req, result, rpcErr)
+	}
+}
+
+func (s *Server) handleSearchRead(raw json.RawMessage) (interface{}, *odoo.JSONRPCError) {
+	params := struct {
+		Model  string        `json:"model"`
+		Domain []interface{} `json:"domain"`
+		Fields []string      `json:"fields"`
+		Limit  int           `json:"limit"`
+		Offset int           `json:"offset"`
+		Sort   string        `json:"sort"`
+	}{}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, newError(200, "Odoo Server Error", err.Error())
+	}
+	matches, err := s.compileDomain(params.Domain)
+	if err != nil {
+		return nil, newError(200, "Odoo Server Error", err.Error())
+	}
+	found := make([]Record, 0)
+	for _, record := range s.sortedRecords(params.Model) {
+		if matches(params.Model, record) {
+			found = append(found, record)
+		}
+	}
+	sortRecords(found, params.Sort)
+	length := len(found)
+	if params.Offset > 0 {
+		if params.Offset > len(found) {
+			params.Offset = len(found)
+		}
+		found = found[params.Offset:]
+	}
+	if params.Limit > 0 && params.Limit < len(found) {
+		found = found[:params.Limit]
+	}
+	records := make([]Record, len(found))
+	for i, record := range found {
+		records[i] = selectFields(record, params.Fields)
+	}
+	return map[string]interface{}{
+		"length":  length,
+		"records": records,
+	}, nil
+}
+
+type callKWParams struct {
+	Model  string            `json:"model"`
+	Method string            `json:"method"`
+	Args   []json.RawMessage `json:"args"`
+}
+
+func (s *Server) handleCreate(raw json.RawMessage) (interface{}, *odoo.JSONRPCError) {
+	params := callKWParams{}
+	if err := json.Unmarshal(raw, &params); err != nil || len(params.Args) < 1 {
+		return nil, newError(200, "Odoo Server Error", "create requires the values as first argument")
+	}
+	values := Record{}
+	if err := json.Unmarshal(params.Args[0], &values); err != nil {
+		return nil, newError(200, "Odoo Server Error", err.Error())
+	}
+	id := s.nextID
+	s.nextID++
+	values["id"] = float64(id)
+	if s.records[params.Model] == nil {
+		s.records[params.Model] = map[int]Record{}
+	}
+	s.records[params.Model][id] = values
+	return id, nil
+}
+
+func (s *Server) handleWrite(raw json.RawMessage) (interface{}, *odoo.JSONRPCError) {
+	params := callKWParams{}
+	if err := json.Unmarshal(raw, &params); err != nil || len(params.Args) < 2 {
+		return nil, newError(200, "Odoo Server Error", "write requires the IDs and values as arguments")
+	}
+	ids := make([]int, 0)
+	values := Record{}
+	if err := json.Unmarshal(params.Args[0], &ids); err != nil {
+		return nil, newError(200, "Odoo Server Error", err.Error())
+	}
+	if err := json.Unmarshal(params.Args[1], &values); err != nil {
+		return nil, newError(200, "Odoo Server Error", err.Error())
+	}
+	for _, id := range ids {
+		record, found := s.records[params.Model][id]
+		if !found {
+			return nil, newError(200, "Odoo Server Error", fmt.Sprintf("record %s(%d) does not exist", params.Model, id))
+		}
+		for key, value := range values {
+			if key == "id" {
+				continue
+			}
+			record[key] = value
+		}
+	}
+	return true, nil
+}
+
+func (s *Server) handleUnlink(raw json.RawMessage) (interface{}, *odoo.JSONRPCError) {
+	params := callKWParams{}
+	if err := json.Unmarshal(raw, &params); err != nil || len(params.Args) < 1 {
+		return nil, newError(200, "Odoo Server Error", "unlink requires the IDs as first argument")
+	}
+	ids := make([]int, 0)
+	if err := json.Unmarshal(params.Args[0], &ids); err != nil {
+		return nil, newError(200, "Odoo Server Error", err.Error())
+	}
+	for _, id := range ids {
+		delete(s.records[params.Model], id)
+	}
+	return true, nil
+}
+
+func (s *Server) sortedRecords(model string) []Record {
+	records := make([]Record, 0, len(s.records[model]))
+	for _, record := range s.records[model] {
+		records = append(records, copyRecord(record))
+	}
+	sortRecords(records, "")
+	return records
+}
+
+// sortRecords sorts by the given "<field> [ASC|DESC]" specification, or by ID if empty.
+func sortRecords(records []Record, spec string) {
+	field, descending := "id", false
+	if parts := strings.Fields(spec); len(parts) > 0 {
+		field = parts[0]
+		descending = len(parts) > 1 && strings.EqualFold(parts[1], "desc")
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if descending {
+			return compareOrdered(records[i][field], records[j][field], ">")
+		}
+		return compareOrdered(records[i][field], records[j][field], "<")
+	})
+}
+
+func selectFields(record Record, fields []string) Record {
+	if len(fields) == 0 {
+		return record
+	}
+	selected := Record{"id": record["id"]}
+	for _, field := range fields {
+		if value, exists := record[field]; exists {
+			selected[field] = value
+		} else {
+			selected[field] = false
+		}
+	}
+	return selected
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request) (rpcRequest, bool) {
+	req := rpcRequest{}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return req, false
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+func writeResponse(w http.ResponseWriter, req rpcRequest, result interface{}, rpcErr *odoo.JSONRPCError) {
+	w.Header().Set("content-type", "application/json")
+	res := rpcResponse{ID: req.ID, JSONRPC: "2.0", Result: result, Error: rpcErr}
+	if rpcErr != nil {
+		res.Result = nil
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func newError(code int, message, detail string) *odoo.JSONRPCError {
+	return &odoo.JSONRPCError{
+		Code:    code,
+		Message: message,
+		Data: map[string]interface{}{
+			"name":    "openerp.exceptions.except_orm",
+			"message": detail,
+		},
+	}
+}
+
+func newSessionExpiredError() *odoo.JSONRPCError {
+	return &odoo.JSONRPCError{
+		Code:    100,
+		Message: "Odoo Session Expired",
+		Data: map[string]interface{}{
+			"name":    "openerp.http.SessionExpiredException",
+			"message": "Session expired",
+		},
+	}
+}
+
+// normalize converts the record through JSON, so that all values have the same types as decoded requests.
+func normalize(record Record) Record {
+	b, err := json.Marshal(record)
+	if err != nil {
+		panic(fmt.Errorf("cannot normalize fixture record: %w", err))
+	}
+	normalized := Record{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		panic(fmt.Errorf("cannot normalize fixture record: %w", err))
+	}
+	return normalized
+}
+
+func copyRecord(record Record) Record {
+	c := make(Record, len(record))
+	for key, value := range record {
+		c[key] = value
+	}
+	return c
+}
@@ -0,0 +1,135 @@
+package odootest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+func newSession(t *testing.T, server *Server) *odoo.Session {
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	return session
+}
+
+func TestServer_Login(t *testing.T) {
+	tests := map[string]struct {
+		givenDB       string
+		givenPassword string
+		expectedError error
+	}{
+		"GivenValidCredentials_ThenReturnSession": {
+			givenDB: "TestDB", givenPassword: "secret",
+		},
+		"GivenWrongPassword_ThenReturnInvalidCredentials": {
+			givenDB: "TestDB", givenPassword: "wrong",
+			expectedError: odoo.ErrInvalidCredentials,
+		},
+	}
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+			require.NoError(t, err)
+			session, err := client.Login(context.Background(), odoo.LoginOptions{DatabaseName: tc.givenDB, Username: "jane", Password: tc.givenPassword})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, session.UID)
+		})
+	}
+}
+
+func TestServer_SearchRead(t *testing.T) {
+	tests := map[string]struct {
+		givenModel  odoo.SearchReadModel
+		expectedIDs []int
+	}{
+		"GivenNoDomain_ThenReturnAll": {
+			givenModel:  odoo.SearchReadModel{Model: "hr.attendance", Fields: []string{"name"}},
+			expectedIDs: []int{1, 2, 3, 4, 5, 6},
+		},
+		"GivenDateRange_ThenReturnMatching": {
+			givenModel: odoo.SearchReadModel{Model: "hr.attendance", Domain: domain.New(
+				domain.Gte("name", "2022-03-02 00:00:00"),
+				domain.Lte("name", "2022-03-02 23:59:59"),
+			)},
+			expectedIDs: []int{5, 6},
+		},
+		"GivenRelatedField_ThenResolvePath": {
+			givenModel:  odoo.SearchReadModel{Model: "hr.employee", Domain: domain.New(domain.Eq("user_id.login", "jane"))},
+			expectedIDs: []int{2},
+		},
+		"GivenOr_ThenReturnEither": {
+			givenModel: odoo.SearchReadModel{Model: "hr.attendance", Domain: domain.New(
+				domain.Or(domain.Eq("id", 1), domain.Eq("id", 6)),
+			)},
+			expectedIDs: []int{1, 6},
+		},
+		"GivenSortAndLimit_ThenReturnPage": {
+			givenModel:  odoo.SearchReadModel{Model: "hr.attendance", Sort: "name DESC", Offset: 1, Limit: 2},
+			expectedIDs: []int{5, 4},
+		},
+		"GivenILike_ThenMatchCaseInsensitive": {
+			givenModel:  odoo.SearchReadModel{Model: "hr.payslip", Domain: domain.New(domain.ILike("name", "march"))},
+			expectedIDs: []int{2},
+		},
+	}
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+	session := newSession(t, server)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := struct {
+				Records []Record `json:"records"`
+			}{}
+			err := session.SearchGenericModel(context.Background(), tc.givenModel, &result)
+			require.NoError(t, err)
+			ids := make([]int, len(result.Records))
+			for i, r := range result.Records {
+				ids[i] = r.ID()
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestServer_CreateUpdateDelete(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+	session := newSession(t, server)
+	ctx := context.Background()
+
+	id, err := session.CreateGenericModel(ctx, "hr.attendance", map[string]interface{}{"name": "2022-03-04 07:00:00", "action": "sign_in"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, id)
+
+	require.NoError(t, session.UpdateGenericModel(ctx, "hr.attendance", id, map[string]interface{}{"action": "sign_out"}))
+	record, found := server.Record("hr.attendance", id)
+	require.True(t, found)
+	assert.Equal(t, "sign_out", record["action"])
+	assert.Equal(t, "2022-03-04 07:00:00", record["name"])
+
+	require.NoError(t, session.DeleteGenericModel(ctx, "hr.attendance", []int{id}))
+	_, found = server.Record("hr.attendance", id)
+	assert.False(t, found)
+}
+
+func TestServer_ExpireSessions(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+	session := newSession(t, server)
+
+	server.ExpireSessions()
+	err := session.SearchGenericModel(context.Background(), odoo.SearchReadModel{Model: "hr.employee"}, &[]Record{})
+	assert.ErrorIs(t, err, odoo.ErrSessionExpired)
+}
@@ -0,0 +1,155 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+// MethodReadGroup is used to aggregate records on the server.
+const MethodReadGroup Method = "read_group"
+
+// Aggregate is an aggregation function that Odoo applies to a field when grouping records.
+type Aggregate string
+
+const (
+	AggregateNone  Aggregate = ""
+	AggregateSum   Aggregate = "sum"
+	AggregateAvg   Aggregate = "avg"
+	AggregateMin   Aggregate = "min"
+	AggregateMax   Aggregate = "max"
+	AggregateCount Aggregate = "count"
+)
+
+// AggregateField is a field that is returned for each group, optionally with an explicit aggregation function.
+type AggregateField struct {
+	Name string
+	// Aggregate is the function to apply.
+	// If AggregateNone, Odoo uses the default aggregation of the field (e.g. "sum" for numeric fields).
+	Aggregate Aggregate
+}
+
+// String returns the field in the `name:aggregate` notation used by Odoo.
+func (f AggregateField) String() string {
+	if f.Aggregate == AggregateNone {
+		return f.Name
+	}
+	return fmt.Sprintf("%s:%s", f.Name, f.Aggregate)
+}
+
+// ReadGroupModel is used as "kwargs" in requests to the "read_group" method.
+type ReadGroupModel struct {
+	Model  string
+	Domain domain.Domain
+	// Fields contains the fields to aggregate.
+	Fields []AggregateField
+	// GroupBy contains the fields to group the records by, e.g. "employee_id" or "date_from:month".
+	GroupBy []string
+	// Lazy groups only by the first field in GroupBy if true.
+	// The remaining GroupBy fields can then be expanded in subsequent requests using ReadGroupResult.Domain.
+	Lazy    bool
+	Limit   int
+	Offset  int
+	OrderBy string
+}
+
+// ReadGroupResult is a single group returned by read_group.
+type ReadGroupResult struct {
+	// Count is the number of records in the group.
+	Count int
+	// Domain is the domain (in Polish notation) that selects the records of this group.
+	Domain []interface{}
+	// Values contains the raw values of the group-by fields and the aggregated fields.
+	// Many2one group-by fields are returned as `[id, "name"]`.
+	Values map[string]json.RawMessage
+}
+
+// Decode unmarshals the ReadGroupResult.Values into the given pointer, e.g. a struct with JSON tags.
+func (r ReadGroupResult) Decode(into interface{}) error {
+	b, err := json.Marshal(r.Values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, into)
+}
+
+// Float returns the given aggregated value as float.
+// Returns 0 if the field is unset or not a number.
+func (r ReadGroupResult) Float(field string) float64 {
+	var f float64
+	if raw, exists := r.Values[field]; exists {
+		_ = json.Unmarshal(raw, &f)
+	}
+	return f
+}
+
+// ReadGroup implements QueryExecutor.
+func (s *Session) ReadGroup(ctx context.Context, model ReadGroupModel) ([]ReadGroupResult, error) {
+	payload := WriteModel{
+		Model:  model.Model,
+		Method: MethodReadGroup,
+		Args:   []interface{}{},
+		KWArgs: readGroupKWArgs(model),
+	}
+	raw := make([]map[string]json.RawMessage, 0)
+	if err := s.ExecuteQuery(ctx, "/web/dataset/call_kw/read_group", payload, &raw); err != nil {
+		return nil, err
+	}
+	return decodeReadGroupResults(raw, model)
+}
+
+func readGroupKWArgs(model ReadGroupModel) map[string]interface{} {
+	fields := make([]string, len(model.Fields))
+	for i, field := range model.Fields {
+		fields[i] = field.String()
+	}
+	kwargs := map[string]interface{}{
+		"domain":  model.Domain,
+		"fields":  fields,
+		"groupby": model.GroupBy,
+		"lazy":    model.Lazy,
+	}
+	if model.Limit > 0 {
+		kwargs["limit"] = model.Limit
+	}
+	if model.Offset > 0 {
+		kwargs["offset"] = model.Offset
+	}
+	if model.OrderBy != "" {
+		kwargs["orderby"] = model.OrderBy
+	}
+	return kwargs
+}
+
+func decodeReadGroupResults(raw []map[string]json.RawMessage, model ReadGroupModel) ([]ReadGroupResult, error) {
+	// In lazy mode, Odoo doesn't return "__count" but "<first groupby>_count".
+	lazyCountKey := ""
+	if model.Lazy && len(model.GroupBy) > 0 {
+		lazyCountKey = strings.Split(model.GroupBy[0], ":")[0] + "_count"
+	}
+	results := make([]ReadGroupResult, len(raw))
+	for i, group := range raw {
+		result := ReadGroupResult{Values: map[string]json.RawMessage{}}
+		for key, value := range group {
+			switch {
+			case key == "__count" || key == lazyCountKey:
+				if err := json.Unmarshal(value, &result.Count); err != nil {
+					return nil, fmt.Errorf("cannot decode %q: %w", key, err)
+				}
+			case key == "__domain":
+				if err := json.Unmarshal(value, &result.Domain); err != nil {
+					return nil, fmt.Errorf("cannot decode %q: %w", key, err)
+				}
+			case strings.HasPrefix(key, "__"):
+				// other metadata like "__context" or "__fold" is ignored
+			default:
+				result.Values[key] = value
+			}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,96 @@
+package odoo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+func TestSession_ReadGroup(t *testing.T) {
+	numRequests := 0
+	uuidGenerator = func() string {
+		return "fakeID"
+	}
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		assert.Equal(t, "/web/dataset/call_kw/read_group", r.RequestURI)
+
+		buf, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id":"fakeID",
+			"jsonrpc":"2.0",
+			"method":"call",
+			"params":{
+				"model":"hr.holidays",
+				"method":"read_group",
+				"args":[],
+				"kwargs":{
+					"domain":[["state","=","validate"]],
+					"fields":["number_of_days_temp:sum"],
+					"groupby":["employee_id","holiday_status_id"],
+					"lazy":false
+				}
+			}}`, string(buf))
+
+		w.Header().Set("content-type", "application/json")
+		_, err = w.Write([]byte(`{
+			"jsonrpc": "2.0",
+			"id": "fakeID",
+			"result": [
+				{
+					"__count": 3,
+					"__domain": ["&", ["employee_id", "=", 1], ["holiday_status_id", "=", 16], ["state", "=", "validate"]],
+					"__context": {"group_by": []},
+					"employee_id": [1, "Employee"],
+					"holiday_status_id": [16, "Legal Leaves 2022"],
+					"number_of_days_temp": 4.5
+				}
+			]
+		}`))
+		require.NoError(t, err)
+	}))
+	defer odooMock.Close()
+
+	// Do request
+	u, err := url.Parse(odooMock.URL)
+	require.NoError(t, err)
+	session := Session{client: &Client{http: http.DefaultClient, parsedURL: u}}
+	result, err := session.ReadGroup(newTestContext(t), ReadGroupModel{
+		Model:   "hr.holidays",
+		Domain:  domain.New(domain.Eq("state", "validate")),
+		Fields:  []AggregateField{{Name: "number_of_days_temp", Aggregate: AggregateSum}},
+		GroupBy: []string{"employee_id", "holiday_status_id"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, numRequests)
+	require.Len(t, result, 1)
+	group := result[0]
+	assert.Equal(t, 3, group.Count)
+	assert.Len(t, group.Domain, 4)
+	assert.Equal(t, 4.5, group.Float("number_of_days_temp"))
+	assert.NotContains(t, group.Values, "__context")
+
+	decoded := struct {
+		EmployeeID []interface{} `json:"employee_id"`
+	}{}
+	require.NoError(t, group.Decode(&decoded))
+	assert.Equal(t, []interface{}{float64(1), "Employee"}, decoded.EmployeeID)
+}
+
+func Test_decodeReadGroupResults_Lazy(t *testing.T) {
+	result, err := decodeReadGroupResults([]map[string]json.RawMessage{
+		{"employee_id_count": json.RawMessage(`2`), "employee_id": json.RawMessage(`[1, "Employee"]`), "__domain": json.RawMessage(`[["employee_id", "=", 1]]`)},
+	}, ReadGroupModel{GroupBy: []string{"employee_id", "date_from:month"}, Lazy: true})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 2, result[0].Count)
+	assert.Len(t, result[0].Values, 1)
+}
@@ -0,0 +1,135 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Many2One is a reference to a single record of another model, e.g. the "holiday_status_id" of a leave.
+//
+// Odoo returns the field as `[id, "display name"]`, or `false` if it's not set.
+// The zero value represents an unset field: it's marshalled as `false` and IsEmpty returns true.
+//
+// MarshalJSON produces the same format that Odoo returns when reading records.
+// Use WriteValue when writing the field with `create` or `write`, since Odoo expects only the ID there.
+type Many2One struct {
+	ID   int
+	Name string
+}
+
+// NewMany2One returns a Many2One that references the record with the given ID.
+// The name is optional and only used for display purposes.
+func NewMany2One(id int, name string) *Many2One {
+	return &Many2One{ID: id, Name: name}
+}
+
+// IsEmpty returns true if the reference is nil or doesn't point to a record.
+func (m *Many2One) IsEmpty() bool {
+	return m == nil || m.ID == 0
+}
+
+// String implements fmt.Stringer.
+// It returns the display name, or an empty string if the reference is nil.
+func (m *Many2One) String() string {
+	if m == nil {
+		return ""
+	}
+	return m.Name
+}
+
+// WriteValue returns the value that sets the field with `create` or `write`: the ID, or `false` to clear the field.
+func (m *Many2One) WriteValue() interface{} {
+	if m.IsEmpty() {
+		return false
+	}
+	return m.ID
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Many2One) MarshalJSON() ([]byte, error) {
+	if m.ID == 0 {
+		return []byte("false"), nil
+	}
+	return json.Marshal([]interface{}{m.ID, m.Name})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Besides the `[id, "name"]` form returned by Odoo it also accepts a plain ID, as used in `create` and `write`.
+func (m *Many2One) UnmarshalJSON(b []byte) error {
+	*m = Many2One{}
+	if isFalseOrNull(b) {
+		return nil
+	}
+	var id int
+	if err := json.Unmarshal(b, &id); err == nil {
+		m.ID = id
+		return nil
+	}
+	var arr []json.RawMessage
+	if err := json.Unmarshal(b, &arr); err != nil {
+		return fmt.Errorf("cannot unmarshal many2one: %w", err)
+	}
+	if len(arr) == 0 {
+		return nil
+	}
+	var floatID float64
+	if err := json.Unmarshal(arr[0], &floatID); err != nil {
+		return fmt.Errorf("cannot unmarshal many2one ID: %w", err)
+	}
+	m.ID = int(floatID)
+	if len(arr) >= 2 {
+		// the name might be `false` if the referenced record has no display name
+		_ = json.Unmarshal(arr[1], &m.Name)
+	}
+	return nil
+}
+
+// Many2Many is a list of IDs of records of another model, e.g. the "users" of a group.
+// The same type is used for One2Many fields, which Odoo returns in the same format.
+//
+// Odoo returns the field as `[1, 2, 3]`.
+// Use WriteValue when writing the field with `create` or `write`, since Odoo expects a list of commands there.
+type Many2Many []int
+
+// One2Many is a list of IDs of records that reference this record, e.g. the "attendance_ids" of an employee.
+type One2Many = Many2Many
+
+// Contains returns true if the list contains the given ID.
+func (m Many2Many) Contains(id int) bool {
+	for _, item := range m {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteValue returns the value that replaces all references of the field with the IDs in the list with `create` or `write`.
+func (m Many2Many) WriteValue() interface{} {
+	ids := []int(m)
+	if ids == nil {
+		ids = []int{}
+	}
+	// (6, 0, ids) is the "replace" command of Odoo's ORM.
+	return []interface{}{[]interface{}{6, 0, ids}}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Many2Many) UnmarshalJSON(b []byte) error {
+	if isFalseOrNull(b) {
+		*m = nil
+		return nil
+	}
+	var ids []int
+	if err := json.Unmarshal(b, &ids); err != nil {
+		return fmt.Errorf("cannot unmarshal %s as list of IDs: %w", string(b), err)
+	}
+	*m = ids
+	return nil
+}
+
+func isFalseOrNull(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) == 0 || string(b) == "false" || string(b) == "null"
+}
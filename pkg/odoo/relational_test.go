@@ -0,0 +1,126 @@
+package odoo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMany2One_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		givenInput     string
+		expectedResult Many2One
+		expectedError  string
+	}{
+		"GivenFalse_ThenExpectEmpty": {
+			givenInput: `false`,
+		},
+		"GivenNull_ThenExpectEmpty": {
+			givenInput: `null`,
+		},
+		"GivenIDAndName_ThenExpectBoth": {
+			givenInput:     `[4, "Sick / Medical Consultation"]`,
+			expectedResult: Many2One{ID: 4, Name: "Sick / Medical Consultation"},
+		},
+		"GivenFloatID_ThenExpectInt": {
+			givenInput:     `[19.0, "Human Resources"]`,
+			expectedResult: Many2One{ID: 19, Name: "Human Resources"},
+		},
+		"GivenNameFalse_ThenExpectIDOnly": {
+			givenInput:     `[7, false]`,
+			expectedResult: Many2One{ID: 7},
+		},
+		"GivenPlainID_ThenExpectIDOnly": {
+			givenInput:     `12`,
+			expectedResult: Many2One{ID: 12},
+		},
+		"GivenString_ThenExpectError": {
+			givenInput:    `"Human Resources"`,
+			expectedError: "cannot unmarshal many2one",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Many2One{ID: 99, Name: "previous"}
+			err := json.Unmarshal([]byte(tc.givenInput), &result)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestMany2One_MarshalJSON(t *testing.T) {
+	type record struct {
+		Value    Many2One  `json:"value"`
+		Pointer  *Many2One `json:"pointer,omitempty"`
+		Optional *Many2One `json:"optional,omitempty"`
+	}
+	result, err := json.Marshal(record{Pointer: NewMany2One(4, "Unpaid")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"value":false,"pointer":[4,"Unpaid"]}`, string(result))
+}
+
+func TestMany2One_WriteValue(t *testing.T) {
+	var unset *Many2One
+	assert.Equal(t, false, unset.WriteValue())
+	assert.Equal(t, false, (&Many2One{Name: "without ID"}).WriteValue())
+	assert.Equal(t, 4, NewMany2One(4, "Unpaid").WriteValue())
+	assert.True(t, unset.IsEmpty())
+	assert.Equal(t, "", unset.String())
+}
+
+func TestMany2Many(t *testing.T) {
+	tests := map[string]struct {
+		givenInput     string
+		expectedResult Many2Many
+		expectedError  string
+	}{
+		"GivenFalse_ThenExpectNil": {
+			givenInput: `false`,
+		},
+		"GivenEmptyList_ThenExpectEmpty": {
+			givenInput:     `[]`,
+			expectedResult: Many2Many{},
+		},
+		"GivenIDs_ThenExpectIDs": {
+			givenInput:     `[1, 8, 23]`,
+			expectedResult: Many2Many{1, 8, 23},
+		},
+		"GivenNonIDs_ThenExpectError": {
+			givenInput:    `[[1, "Admin"]]`,
+			expectedError: `cannot unmarshal [[1, "Admin"]] as list of IDs`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var result Many2Many
+			err := json.Unmarshal([]byte(tc.givenInput), &result)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestMany2Many_WriteValue(t *testing.T) {
+	ids := Many2Many{1, 8}
+	assert.True(t, ids.Contains(8))
+	assert.False(t, ids.Contains(2))
+
+	result, err := json.Marshal(ids.WriteValue())
+	require.NoError(t, err)
+	assert.JSONEq(t, `[[6,0,[1,8]]]`, string(result))
+
+	result, err = json.Marshal(Many2Many(nil).WriteValue())
+	require.NoError(t, err)
+	assert.JSONEq(t, `[[6,0,[]]]`, string(result), "empty list clears the field")
+}
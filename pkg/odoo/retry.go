@@ -0,0 +1,117 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// RetryPolicy defines how often and how fast idempotent reads are retried after transient failures.
+//
+// Only search_read queries of Session, XMLRPCSession and APIKeySession are retried, since they don't change any data in Odoo.
+// Queries that create, write or unlink records are never retried automatically.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// A value of 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	// The delay doubles with each further retry, and a random jitter of up to half the delay is subtracted.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the RetryPolicy that is used if ClientOptions.RetryPolicy is empty.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+	}
+}
+
+// HTTPStatusError is returned if Odoo, or a proxy in front of it, responds with an unexpected HTTP status.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+// Error implements error.
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("expected HTTP status 200 OK, got %s", e.Status)
+}
+
+// networkError wraps errors that occurred while sending a request or receiving the response.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return fmt.Sprintf("sending HTTP request: %s", e.err)
+}
+
+func (e *networkError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable returns true if the query may succeed when sent again.
+func isRetryable(err error) bool {
+	netErr := &networkError{}
+	if errors.As(err, &netErr) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	statusErr := &HTTPStatusError{}
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	return IsTransient(err)
+}
+
+// backoff returns the delay before the given retry, starting with 1 for the first retry.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if half := int64(delay / 2); half > 0 {
+		delay -= time.Duration(rand.Int63n(half)) // #nosec G404 -- jitter doesn't need a secure random source
+	}
+	return delay
+}
+
+// do calls fn until it succeeds, returns an error that isn't retryable, or the attempts are exhausted.
+// It gives up early if the context is done or its deadline would pass while waiting for the next attempt.
+// Each retry is logged with the logger of the context.
+func (p RetryPolicy) do(ctx context.Context, model string, fn func() error) error {
+	logger := logr.FromContextOrDiscard(ctx)
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !isRetryable(err) {
+			return err
+		}
+		delay := p.backoff(attempt)
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && time.Now().Add(delay).After(deadline) {
+			return err
+		}
+		logger.Info("Retrying Odoo query", "model", model, "attempt", attempt+1, "maxAttempts", p.MaxAttempts, "delay", delay.String(), "error", err.Error())
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
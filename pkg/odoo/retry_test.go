@@ -0,0 +1,164 @@
+package odoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_Retry(t *testing.T) {
+	const transientError = `{"jsonrpc":"2.0","id":"1","error":{"code":200,"message":"Odoo Server Error","data":{"name":"psycopg2.extensions.TransactionRollbackError","message":"could not serialize access due to concurrent update"}}}`
+	const accessError = `{"jsonrpc":"2.0","id":"1","error":{"code":200,"message":"Odoo Server Error","data":{"name":"openerp.exceptions.AccessError","message":"forbidden"}}}`
+	tests := map[string]struct {
+		givenResponses   []func(w http.ResponseWriter)
+		givenQuery       func(s *Session) error
+		expectedRequests int
+		expectedError    string
+	}{
+		"GivenBadGateway_WhenSearching_ThenRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithStatus(http.StatusBadGateway),
+				respondWithBody(`{"jsonrpc":"2.0","id":"1","result":{"length":0,"records":[]}}`),
+			},
+			givenQuery:       search,
+			expectedRequests: 2,
+		},
+		"GivenTransientOdooError_WhenSearching_ThenRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithBody(transientError),
+				respondWithStatus(http.StatusServiceUnavailable),
+				respondWithBody(`{"jsonrpc":"2.0","id":"1","result":{"length":0,"records":[]}}`),
+			},
+			givenQuery:       search,
+			expectedRequests: 3,
+		},
+		"GivenPersistentFailure_WhenSearching_ThenGiveUpAfterMaxAttempts": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithStatus(http.StatusGatewayTimeout),
+			},
+			givenQuery:       search,
+			expectedRequests: 3,
+			expectedError:    "expected HTTP status 200 OK, got 504 Gateway Timeout",
+		},
+		"GivenAccessError_WhenSearching_ThenDontRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithBody(accessError),
+			},
+			givenQuery:       search,
+			expectedRequests: 1,
+			expectedError:    "model search_read: openerp.exceptions.AccessError: forbidden",
+		},
+		"GivenInternalServerError_WhenSearching_ThenDontRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithStatus(http.StatusInternalServerError),
+			},
+			givenQuery:       search,
+			expectedRequests: 1,
+			expectedError:    "expected HTTP status 200 OK, got 500 Internal Server Error",
+		},
+		"GivenBadGateway_WhenWriting_ThenDontRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithStatus(http.StatusBadGateway),
+			},
+			givenQuery: func(s *Session) error {
+				return s.UpdateGenericModel(context.Background(), "model", 1, map[string]interface{}{})
+			},
+			expectedRequests: 1,
+			expectedError:    "expected HTTP status 200 OK, got 502 Bad Gateway",
+		},
+		"GivenTransientOdooError_WhenCreating_ThenDontRetry": {
+			givenResponses: []func(w http.ResponseWriter){
+				respondWithBody(transientError),
+			},
+			givenQuery: func(s *Session) error {
+				_, err := s.CreateGenericModel(context.Background(), "model", map[string]interface{}{})
+				return err
+			},
+			expectedRequests: 1,
+			expectedError:    "model create: psycopg2.extensions.TransactionRollbackError: could not serialize access due to concurrent update",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			numRequests := 0
+			odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				respond := tc.givenResponses[len(tc.givenResponses)-1]
+				if numRequests < len(tc.givenResponses) {
+					respond = tc.givenResponses[numRequests]
+				}
+				numRequests++
+				respond(w)
+			}))
+			defer odooMock.Close()
+
+			session := newRetryTestSession(t, odooMock.URL, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+			err := tc.givenQuery(session)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedRequests, numRequests, "number of requests")
+		})
+	}
+}
+
+func TestSession_Retry_RespectsContextDeadline(t *testing.T) {
+	numRequests := 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer odooMock.Close()
+
+	session := newRetryTestSession(t, odooMock.URL, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := session.SearchGenericModel(ctx, SearchReadModel{Model: "model"}, &struct{}{})
+	assert.EqualError(t, err, "expected HTTP status 200 OK, got 502 Bad Gateway")
+	assert.Equal(t, 1, numRequests, "the backoff exceeds the deadline, so no retry is expected")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for i := 0; i < 20; i++ {
+		assert.GreaterOrEqual(t, p.backoff(1), 50*time.Millisecond)
+		assert.LessOrEqual(t, p.backoff(1), 100*time.Millisecond)
+		assert.GreaterOrEqual(t, p.backoff(2), 100*time.Millisecond)
+		assert.LessOrEqual(t, p.backoff(2), 200*time.Millisecond)
+		assert.GreaterOrEqual(t, p.backoff(5), 150*time.Millisecond)
+		assert.LessOrEqual(t, p.backoff(5), 300*time.Millisecond)
+	}
+}
+
+func search(s *Session) error {
+	return s.SearchGenericModel(context.Background(), SearchReadModel{Model: "model"}, &struct{}{})
+}
+
+func respondWithStatus(status int) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(status)
+	}
+}
+
+func respondWithBody(body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func newRetryTestSession(t *testing.T, baseURL string, policy RetryPolicy) *Session {
+	u, err := url.Parse(baseURL)
+	require.NoError(t, err)
+	return &Session{client: &Client{http: &http.Client{}, parsedURL: u, retryPolicy: policy}}
+}
@@ -0,0 +1,126 @@
+package odoo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RPCError is an error returned by Odoo in response to a query.
+// Use errors.As to inspect it, or one of the helpers like IsAccessDenied.
+type RPCError struct {
+	// Code is the JSON-RPC error code, usually 200 for server exceptions.
+	Code int
+	// Name is the name of the Odoo exception, e.g. "openerp.exceptions.AccessError".
+	Name string
+	// Message is the message of the Odoo exception.
+	Message string
+	// Debug contains the traceback of the Odoo exception.
+	Debug string
+	// Model is the name of the model that was queried, if known.
+	Model string
+	// Method is the method that was called on the model, e.g. "search_read", if known.
+	Method string
+}
+
+// Error implements error.
+func (e *RPCError) Error() string {
+	b := strings.Builder{}
+	if e.Model != "" {
+		b.WriteString(fmt.Sprintf("%s %s: ", e.Model, e.Method))
+	}
+	b.WriteString(e.Name)
+	if e.Message != "" {
+		if e.Name != "" {
+			b.WriteString(": ")
+		}
+		b.WriteString(e.Message)
+	}
+	return b.String()
+}
+
+// newRPCError converts the error object of a JSON-RPC response.
+func newRPCError(jsonErr *JSONRPCError) *RPCError {
+	rpcErr := &RPCError{Code: jsonErr.Code, Message: jsonErr.Message}
+	if name, ok := jsonErr.Data["name"].(string); ok {
+		rpcErr.Name = name
+	}
+	// The top-level message is generic like "Odoo Server Error", the actual message is in the data.
+	if message, ok := jsonErr.Data["message"].(string); ok && message != "" {
+		rpcErr.Message = message
+	}
+	if debug, ok := jsonErr.Data["debug"].(string); ok {
+		rpcErr.Debug = debug
+	}
+	return rpcErr
+}
+
+// withQuery sets the model and method of the query if err is an *RPCError.
+func withQuery(err error, model, method string) error {
+	rpcErr := &RPCError{}
+	if errors.As(err, &rpcErr) && rpcErr.Model == "" {
+		rpcErr.Model = model
+		rpcErr.Method = method
+	}
+	return err
+}
+
+// exceptionName returns the unqualified name of the Odoo exception, e.g. "AccessError".
+func (e *RPCError) exceptionName() string {
+	return e.Name[strings.LastIndex(e.Name, ".")+1:]
+}
+
+// IsAccessDenied returns true if err is an *RPCError caused by missing access rights or rules.
+func IsAccessDenied(err error) bool {
+	rpcErr := &RPCError{}
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	switch rpcErr.exceptionName() {
+	case "AccessError", "AccessDenied":
+		return true
+	}
+	return false
+}
+
+// IsMissingField returns true if err is an *RPCError caused by a field that doesn't exist on the model.
+// This usually means that the Odoo version or the installed modules don't match the expected schema.
+func IsMissingField(err error) bool {
+	rpcErr := &RPCError{}
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	switch rpcErr.exceptionName() {
+	case "ValueError", "KeyError":
+		return strings.Contains(rpcErr.Message, "Invalid field") || strings.Contains(rpcErr.Debug, "Invalid field")
+	}
+	return false
+}
+
+// IsValidationError returns true if err is an *RPCError caused by invalid data, e.g. a constraint violated by a write.
+// The generic exceptions of Odoo 8 ("Warning", "except_orm" and "except_osv") aren't considered validation errors, since Odoo raises them for access and integrity errors as well.
+func IsValidationError(err error) bool {
+	rpcErr := &RPCError{}
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	switch rpcErr.exceptionName() {
+	case "ValidationError", "UserError":
+		return true
+	}
+	return false
+}
+
+// IsTransient returns true if err is an *RPCError caused by a temporary condition in Odoo, e.g. a database serialization failure between concurrent transactions.
+// Read-only queries that fail with a transient error may succeed if sent again.
+func IsTransient(err error) bool {
+	rpcErr := &RPCError{}
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	switch rpcErr.exceptionName() {
+	case "TransactionRollbackError", "SerializationFailure", "OperationalError", "InterfaceError":
+		return true
+	}
+	return false
+}
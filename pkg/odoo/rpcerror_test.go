@@ -0,0 +1,101 @@
+package odoo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_SearchGenericModel_RPCError(t *testing.T) {
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		_, err := w.Write([]byte(`{
+			"jsonrpc": "2.0",
+			"id": "fakeID",
+			"error": {
+				"code": 200,
+				"message": "Odoo Server Error",
+				"data": {
+					"debug": "Traceback xxx",
+					"message": "Sorry, you are not allowed to access this document.",
+					"name": "openerp.exceptions.AccessError",
+					"arguments": ["Sorry, you are not allowed to access this document."]
+				}
+			}
+		}`))
+		require.NoError(t, err)
+	}))
+	defer odooMock.Close()
+
+	u, err := url.Parse(odooMock.URL)
+	require.NoError(t, err)
+	session := Session{client: &Client{http: http.DefaultClient, parsedURL: u}}
+	err = session.SearchGenericModel(newTestContext(t), SearchReadModel{Model: "hr.payslip"}, &List[interface{}]{})
+
+	rpcErr := &RPCError{}
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, &RPCError{
+		Code:    200,
+		Name:    "openerp.exceptions.AccessError",
+		Message: "Sorry, you are not allowed to access this document.",
+		Debug:   "Traceback xxx",
+		Model:   "hr.payslip",
+		Method:  "search_read",
+	}, rpcErr)
+	assert.EqualError(t, err, "hr.payslip search_read: openerp.exceptions.AccessError: Sorry, you are not allowed to access this document.")
+	assert.True(t, IsAccessDenied(err))
+	assert.False(t, IsMissingField(err))
+}
+
+func TestRPCError_Helpers(t *testing.T) {
+	tests := map[string]struct {
+		givenError                error
+		expectedAccessDenied      bool
+		expectedMissingField      bool
+		expectedValidationFailure bool
+		expectedTransient         bool
+	}{
+		"GivenAccessError": {
+			givenError:           &RPCError{Name: "openerp.exceptions.AccessError"},
+			expectedAccessDenied: true,
+		},
+		"GivenAccessDenied": {
+			givenError:           &RPCError{Name: "AccessDenied"},
+			expectedAccessDenied: true,
+		},
+		"GivenInvalidField": {
+			givenError:           &RPCError{Name: "exceptions.ValueError", Message: "Invalid field 'x_overtime' in leaf"},
+			expectedMissingField: true,
+		},
+		"GivenOtherValueError": {
+			givenError: &RPCError{Name: "exceptions.ValueError", Message: "invalid literal for int()"},
+		},
+		"GivenValidationError": {
+			givenError:                &RPCError{Name: "openerp.exceptions.ValidationError"},
+			expectedValidationFailure: true,
+		},
+		"GivenOdoo8ExceptOrmAccessError": {
+			givenError: &RPCError{Name: "openerp.osv.orm.except_orm", Message: "Access Denied\nThe requested operation cannot be completed due to security restrictions."},
+		},
+		"GivenSerializationFailure": {
+			givenError:        &RPCError{Name: "psycopg2.extensions.TransactionRollbackError"},
+			expectedTransient: true,
+		},
+		"GivenOtherError": {
+			givenError: errors.New("access denied"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedAccessDenied, IsAccessDenied(tc.givenError), "IsAccessDenied")
+			assert.Equal(t, tc.expectedMissingField, IsMissingField(tc.givenError), "IsMissingField")
+			assert.Equal(t, tc.expectedValidationFailure, IsValidationError(tc.givenError), "IsValidationError")
+			assert.Equal(t, tc.expectedTransient, IsTransient(tc.givenError), "IsTransient")
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MethodFieldsGet is used to retrieve the field definitions of a model.
+const MethodFieldsGet Method = "fields_get"
+
+// Field is the definition of a model's field as returned by "fields_get".
+type Field struct {
+	// Type is the Odoo field type, e.g. "char", "many2one" or "datetime".
+	Type string `json:"type"`
+	// Relation is the name of the referenced model of relational fields.
+	Relation string `json:"relation,omitempty"`
+	// String is the label of the field.
+	String string `json:"string,omitempty"`
+}
+
+// FieldsGet returns the definitions of all fields of the given model by field name.
+func FieldsGet(ctx context.Context, querier QueryExecutor, model string) (map[string]Field, error) {
+	payload := WriteModel{
+		Model:  model,
+		Method: MethodFieldsGet,
+		Args:   []interface{}{},
+		KWArgs: map[string]interface{}{"attributes": []string{"type", "relation", "string"}},
+	}
+	result := map[string]Field{}
+	err := querier.ExecuteQuery(ctx, "/web/dataset/call_kw/fields_get", payload, &result)
+	return result, err
+}
+
+// FieldSpec is a field that is expected to exist on a model.
+type FieldSpec struct {
+	Name string
+	// Types contains the accepted field types.
+	// Any type is accepted if empty.
+	Types []string
+}
+
+// NewFieldSpec returns a FieldSpec that accepts the given types.
+func NewFieldSpec(name string, types ...string) FieldSpec {
+	return FieldSpec{Name: name, Types: types}
+}
+
+// ModelSchema contains the fields that are expected to exist on a model.
+type ModelSchema struct {
+	Model  string
+	Fields []FieldSpec
+}
+
+// SchemaProblemKind describes what's wrong with a field or model.
+type SchemaProblemKind string
+
+const (
+	// SchemaMissingModel means that Odoo doesn't know the model, e.g. because a module isn't installed.
+	SchemaMissingModel SchemaProblemKind = "missing model"
+	// SchemaMissingField means that the model doesn't have the field.
+	SchemaMissingField SchemaProblemKind = "missing field"
+	// SchemaTypeMismatch means that the field exists, but has a different type than expected.
+	SchemaTypeMismatch SchemaProblemKind = "type mismatch"
+)
+
+// SchemaProblem is a deviation of the Odoo schema from a ModelSchema.
+type SchemaProblem struct {
+	Kind  SchemaProblemKind
+	Model string
+	// Field is empty if the whole model is missing.
+	Field string
+	// Expected contains the accepted field types of a type mismatch.
+	Expected []string
+	// Actual is the field type in Odoo of a type mismatch, or the error returned by Odoo for a missing model.
+	Actual string
+}
+
+// String implements fmt.Stringer.
+func (p SchemaProblem) String() string {
+	switch p.Kind {
+	case SchemaMissingModel:
+		return fmt.Sprintf("%s: %s (%s)", p.Model, p.Kind, p.Actual)
+	case SchemaTypeMismatch:
+		return fmt.Sprintf("%s.%s: %s: expected %s, got %s", p.Model, p.Field, p.Kind, strings.Join(p.Expected, " or "), p.Actual)
+	}
+	return fmt.Sprintf("%s.%s: %s", p.Model, p.Field, p.Kind)
+}
+
+// SchemaError is returned by VerifySchema if the schema has problems.
+type SchemaError struct {
+	Problems []SchemaProblem
+}
+
+// Error implements error.
+func (e *SchemaError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		lines[i] = problem.String()
+	}
+	return fmt.Sprintf("Odoo schema doesn't match the expectations: %s", strings.Join(lines, "; "))
+}
+
+// VerifySchema calls "fields_get" for each model and compares the fields with the expected ones.
+// It returns a *SchemaError containing all problems if there are any.
+// Other errors, e.g. if Odoo isn't reachable or the user lacks access rights, are returned as-is.
+func VerifySchema(ctx context.Context, querier QueryExecutor, schemas []ModelSchema) error {
+	problems := make([]SchemaProblem, 0)
+	for _, schema := range schemas {
+		fields, err := FieldsGet(ctx, querier, schema.Model)
+		if err != nil {
+			rpcErr := &RPCError{}
+			if errors.As(err, &rpcErr) && !IsAccessDenied(err) {
+				problems = append(problems, SchemaProblem{Kind: SchemaMissingModel, Model: schema.Model, Actual: rpcErr.Message})
+				continue
+			}
+			return fmt.Errorf("cannot get fields of %s: %w", schema.Model, err)
+		}
+		problems = append(problems, compareFields(schema, fields)...)
+	}
+	if len(problems) > 0 {
+		return &SchemaError{Problems: problems}
+	}
+	return nil
+}
+
+func compareFields(schema ModelSchema, fields map[string]Field) []SchemaProblem {
+	problems := make([]SchemaProblem, 0)
+	for _, spec := range schema.Fields {
+		field, exists := fields[spec.Name]
+		if !exists {
+			problems = append(problems, SchemaProblem{Kind: SchemaMissingField, Model: schema.Model, Field: spec.Name})
+			continue
+		}
+		if len(spec.Types) > 0 && !containsString(spec.Types, field.Type) {
+			problems = append(problems, SchemaProblem{Kind: SchemaTypeMismatch, Model: schema.Model, Field: spec.Name, Expected: spec.Types, Actual: field.Type})
+		}
+	}
+	return problems
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
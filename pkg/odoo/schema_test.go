@@ -0,0 +1,79 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySchema(t *testing.T) {
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/web/dataset/call_kw/fields_get", r.URL.Path)
+		request := struct {
+			Params WriteModel `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, MethodFieldsGet, request.Params.Method)
+
+		w.Header().Set("content-type", "application/json")
+		switch request.Params.Model {
+		case "hr.payslip":
+			_, _ = w.Write([]byte(`{"id":"1","jsonrpc":"2.0","result":{
+				"name": {"type": "char", "string": "Payslip Name"},
+				"x_overtime": {"type": "float", "string": "Overtime"},
+				"employee_id": {"type": "many2one", "relation": "hr.employee", "string": "Employee"}
+			}}`))
+		case "hr.leave":
+			_, _ = w.Write([]byte(`{"id":"1","jsonrpc":"2.0","error":{"code":200,"message":"Odoo Server Error","data":{"name":"exceptions.KeyError","message":"hr.leave"}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"1","jsonrpc":"2.0","result":{"name": {"type": "char"}}}`))
+		}
+	}))
+	defer odooMock.Close()
+
+	client, err := NewClient(odooMock.URL, ClientOptions{})
+	require.NoError(t, err)
+	session := RestoreSession(client, "session", 1)
+
+	err = VerifySchema(context.Background(), session, []ModelSchema{
+		{Model: "res.users", Fields: []FieldSpec{NewFieldSpec("name", "char")}},
+		{Model: "hr.payslip", Fields: []FieldSpec{
+			NewFieldSpec("name", "char"),
+			NewFieldSpec("x_overtime", "char", "text"),
+			NewFieldSpec("x_timezone"),
+			NewFieldSpec("employee_id"),
+		}},
+		{Model: "hr.leave", Fields: []FieldSpec{NewFieldSpec("name")}},
+	})
+
+	schemaErr := &SchemaError{}
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []SchemaProblem{
+		{Kind: SchemaTypeMismatch, Model: "hr.payslip", Field: "x_overtime", Expected: []string{"char", "text"}, Actual: "float"},
+		{Kind: SchemaMissingField, Model: "hr.payslip", Field: "x_timezone"},
+		{Kind: SchemaMissingModel, Model: "hr.leave", Actual: "hr.leave"},
+	}, schemaErr.Problems)
+	assert.EqualError(t, err, "Odoo schema doesn't match the expectations: "+
+		"hr.payslip.x_overtime: type mismatch: expected char or text, got float; "+
+		"hr.payslip.x_timezone: missing field; "+
+		"hr.leave: missing model (hr.leave)")
+}
+
+func TestVerifySchema_GivenNoProblems_ThenExpectNoError(t *testing.T) {
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","jsonrpc":"2.0","result":{"name": {"type": "char"}}}`))
+	}))
+	defer odooMock.Close()
+
+	client, err := NewClient(odooMock.URL, ClientOptions{})
+	require.NoError(t, err)
+	err = VerifySchema(context.Background(), RestoreSession(client, "session", 1), []ModelSchema{
+		{Model: "res.users", Fields: []FieldSpec{NewFieldSpec("name", "char")}},
+	})
+	assert.NoError(t, err)
+}
@@ -11,6 +11,8 @@ import (
 var (
 	// ErrInvalidCredentials is an error that indicates an authentication error due to missing or invalid credentials.
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrSessionExpired is an error that indicates that Odoo doesn't accept the session ID anymore and a new login is required.
+	ErrSessionExpired = errors.New("session expired")
 )
 
 //go:generate go run github.com/golang/mock/mockgen -destination=./odoomock/$GOFILE -package odoomock github.com/vshn/appuio-odoo-adapter/odoo QueryExecutor
@@ -27,6 +29,8 @@ type QueryExecutor interface {
 	// DeleteGenericModel accepts a model identifier and data records IDs as payload and executes a query to delete multiple existing data records.
 	// At least one ID is required.
 	DeleteGenericModel(ctx context.Context, model string, ids []int) error
+	// ReadGroup accepts a ReadGroupModel and returns the records aggregated by Odoo into groups.
+	ReadGroup(ctx context.Context, model ReadGroupModel) ([]ReadGroupResult, error)
 	// ExecuteQuery runs a generic JSONRPC query with the given model as payload and deserializes the response.
 	ExecuteQuery(ctx context.Context, path string, model interface{}, into interface{}) error
 }
@@ -100,14 +104,24 @@ func (s *Session) DeleteGenericModel(ctx context.Context, model string, ids []in
 }
 
 // ExecuteQuery implements QueryExecutor.
+// Queries with a SearchReadModel are retried according to ClientOptions.RetryPolicy if they fail with a transient error.
 func (s *Session) ExecuteQuery(ctx context.Context, path string, model interface{}, into interface{}) error {
+	if m, isSearch := model.(SearchReadModel); isSearch {
+		return s.client.retryPolicy.do(ctx, m.Model, func() error {
+			return s.executeQuery(ctx, path, model, into)
+		})
+	}
+	return s.executeQuery(ctx, path, model, into)
+}
+
+func (s *Session) executeQuery(ctx context.Context, path string, model interface{}, into interface{}) error {
 	body, err := NewJSONRPCRequest(&model).Encode()
 	if err != nil {
 		return newEncodingRequestError(err)
 	}
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.parsedURL.String()+path, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.endpoint(path), body)
 	if err != nil {
 		return newCreatingRequestError(err)
 	}
@@ -118,15 +132,23 @@ func (s *Session) ExecuteQuery(ctx context.Context, path string, model interface
 	if err != nil {
 		return err
 	}
-	return s.unmarshalResponse(resp.Body, into)
+	err = s.unmarshalResponse(resp.Body, into)
+	switch m := model.(type) {
+	case SearchReadModel:
+		return withQuery(err, m.Model, "search_read")
+	case WriteModel:
+		return withQuery(err, m.Model, string(m.Method))
+	}
+	return err
 }
 
 func (s *Session) sendRequest(req *http.Request) (*http.Response, error) {
 	res, err := s.client.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("sending HTTP request: %w", err)
+		return nil, &networkError{err: err}
 	} else if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("expected HTTP status 200 OK, got %s", res.Status)
+		res.Body.Close()
+		return nil, &HTTPStatusError{StatusCode: res.StatusCode, Status: res.Status}
 	}
 	return res, nil
 }
@@ -134,7 +156,7 @@ func (s *Session) sendRequest(req *http.Request) (*http.Response, error) {
 func (s *Session) unmarshalResponse(body io.ReadCloser, into interface{}) error {
 	defer body.Close()
 	if err := DecodeResult(body, into); err != nil {
-		return fmt.Errorf("decoding result: %w", err)
+		return newDecodingResultError(err)
 	}
 	return nil
 }
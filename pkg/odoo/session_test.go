@@ -49,7 +49,7 @@ func TestSession_CreateGenericModel(t *testing.T) {
 	u, err := url.Parse(odooMock.URL)
 	require.NoError(t, err)
 	session := Session{client: &Client{http: http.DefaultClient, parsedURL: u}}
-	session.client.http.Transport = newDebugTransport()
+	session.client.http.Transport = newDebugTransport(http.DefaultTransport)
 	result, err := session.CreateGenericModel(newTestContext(t), "model", "data")
 	require.NoError(t, err)
 	assert.Equal(t, 221, result)
@@ -95,7 +95,7 @@ func TestSession_UpdateGenericModel(t *testing.T) {
 	u, err := url.Parse(odooMock.URL)
 	require.NoError(t, err)
 	session := Session{client: &Client{http: http.DefaultClient, parsedURL: u}}
-	session.client.http.Transport = newDebugTransport()
+	session.client.http.Transport = newDebugTransport(http.DefaultTransport)
 	err = session.UpdateGenericModel(newTestContext(t), "model", 1, "data")
 	require.NoError(t, err)
 	assert.Equal(t, 1, numRequests)
@@ -137,8 +137,37 @@ func TestSession_DeleteGenericModel(t *testing.T) {
 	u, err := url.Parse(odooMock.URL)
 	require.NoError(t, err)
 	session := Session{client: &Client{http: http.DefaultClient, parsedURL: u}}
-	session.client.http.Transport = newDebugTransport()
+	session.client.http.Transport = newDebugTransport(http.DefaultTransport)
 	err = session.DeleteGenericModel(newTestContext(t), "model", []int{100})
 	require.NoError(t, err)
 	assert.Equal(t, 1, numRequests)
 }
+
+func TestSession_SearchGenericModel_SessionExpired(t *testing.T) {
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		_, err := w.Write([]byte(`{
+			"jsonrpc": "2.0",
+			"id": "fakeID",
+			"error": {
+				"code": 100,
+				"message": "Odoo Session Expired",
+				"data": {
+					"debug": "Traceback xxx",
+					"message": "Session expired",
+					"name": "openerp.http.SessionExpiredException",
+					"arguments": ["Session expired"]
+				}
+			}
+		}`))
+		require.NoError(t, err)
+	}))
+	defer odooMock.Close()
+
+	// Do request
+	u, err := url.Parse(odooMock.URL)
+	require.NoError(t, err)
+	session := Session{client: &Client{http: http.DefaultClient, parsedURL: u}}
+	err = session.SearchGenericModel(newTestContext(t), SearchReadModel{Model: "model"}, &List[interface{}]{})
+	assert.ErrorIs(t, err, ErrSessionExpired)
+}
@@ -0,0 +1,108 @@
+package odoo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultTimeout is the default maximum duration of a request to Odoo.
+const DefaultTimeout = 10 * time.Second
+
+// TransportOptions configures the HTTP connections to Odoo.
+type TransportOptions struct {
+	// Timeout is the maximum duration of a single request to Odoo, including reading the response.
+	// Defaults to DefaultTimeout if 0.
+	Timeout time.Duration
+	// CAFile is the path to a PEM-encoded bundle of certificate authorities that are trusted in addition to the system's pool.
+	CAFile string
+	// InsecureSkipVerify disables the verification of Odoo's TLS certificate.
+	// This should only be used in test environments.
+	InsecureSkipVerify bool
+	// ProxyURL is the URL of the HTTP proxy to connect through.
+	// If empty, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// MaxIdleConns limits the number of idle connections that are kept open for reuse.
+	// Uses the defaults of http.DefaultTransport if 0.
+	MaxIdleConns int
+	// MaxConnsPerHost limits the number of connections to Odoo, including connections that are in use.
+	// There is no limit if 0.
+	MaxConnsPerHost int
+	// RoundTripper replaces the transport that would be built from the TLS, proxy and connection pool settings above.
+	// The cassette and debug logger still wrap it.
+	RoundTripper http.RoundTripper
+	// WrapTransport is called with the base transport and may return a transport that wraps it, e.g. to record metrics.
+	// The cassette and debug logger wrap the returned transport.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+}
+
+func (o TransportOptions) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultTimeout
+}
+
+// newTransport returns the base http.RoundTripper configured by the options.
+func newTransport(options TransportOptions) (http.RoundTripper, error) {
+	transport, err := newBaseTransport(options)
+	if err != nil || options.WrapTransport == nil {
+		return transport, err
+	}
+	return options.WrapTransport(transport), nil
+}
+
+func newBaseTransport(options TransportOptions) (http.RoundTripper, error) {
+	if options.RoundTripper != nil {
+		return options.RoundTripper, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if options.CAFile != "" || options.InsecureSkipVerify {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+		tlsConfig.InsecureSkipVerify = options.InsecureSkipVerify // #nosec G402 -- opt-in for test environments
+		if options.CAFile != "" {
+			pool, err := loadCertPool(options.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if options.ProxyURL != "" {
+		proxy, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+		transport.MaxIdleConnsPerHost = options.MaxIdleConns
+	}
+	transport.MaxConnsPerHost = options.MaxConnsPerHost
+	return transport, nil
+}
+
+// loadCertPool returns the system's certificate pool with the certificates of the given PEM file added.
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", file)
+	}
+	return pool, nil
+}
@@ -0,0 +1,119 @@
+package odoo
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewTransport(t *testing.T) {
+	tests := map[string]struct {
+		givenOptions  TransportOptions
+		expectedError string
+		assertFn      func(t *testing.T, transport *http.Transport)
+	}{
+		"GivenNoOptions_ThenExpectDefaults": {
+			assertFn: func(t *testing.T, transport *http.Transport) {
+				if transport.TLSClientConfig != nil {
+					assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+					assert.Nil(t, transport.TLSClientConfig.RootCAs)
+				}
+				assert.Equal(t, http.DefaultTransport.(*http.Transport).MaxIdleConns, transport.MaxIdleConns)
+				assert.Zero(t, transport.MaxConnsPerHost)
+			},
+		},
+		"GivenInsecureSkipVerify_ThenExpectTLSConfig": {
+			givenOptions: TransportOptions{InsecureSkipVerify: true},
+			assertFn: func(t *testing.T, transport *http.Transport) {
+				assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+			},
+		},
+		"GivenProxyURL_ThenExpectProxy": {
+			givenOptions: TransportOptions{ProxyURL: "http://proxy:3128"},
+			assertFn: func(t *testing.T, transport *http.Transport) {
+				req := httptest.NewRequest(http.MethodPost, "https://odoo.example.com/web", nil)
+				proxy, err := transport.Proxy(req)
+				require.NoError(t, err)
+				assert.Equal(t, "http://proxy:3128", proxy.String())
+			},
+		},
+		"GivenPoolSizing_ThenExpectLimits": {
+			givenOptions: TransportOptions{MaxIdleConns: 5, MaxConnsPerHost: 20},
+			assertFn: func(t *testing.T, transport *http.Transport) {
+				assert.Equal(t, 5, transport.MaxIdleConns)
+				assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+				assert.Equal(t, 20, transport.MaxConnsPerHost)
+			},
+		},
+		"GivenMissingCAFile_ThenExpectError": {
+			givenOptions:  TransportOptions{CAFile: "/does/not/exist.pem"},
+			expectedError: "cannot read CA file: open /does/not/exist.pem: no such file or directory",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := newTransport(tc.givenOptions)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.assertFn(t, result.(*http.Transport))
+		})
+	}
+}
+
+func TestNewClient_WithCAFile(t *testing.T) {
+	odooMock := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"uid":1,"session_id":"abc"}}`))
+	}))
+	defer odooMock.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: odooMock.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, cert, 0o600))
+
+	client, err := NewClient(odooMock.URL, ClientOptions{TransportOptions: TransportOptions{CAFile: caFile}})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), LoginOptions{DatabaseName: "db", Username: "user", Password: "pass"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, session.UID)
+}
+
+func TestNewClient_WithRoundTripper(t *testing.T) {
+	called := false
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"uid":1,"session_id":"abc"}}`))
+	}))
+	defer odooMock.Close()
+
+	client, err := NewClient(odooMock.URL, ClientOptions{
+		UseDebugLogger:   true,
+		TransportOptions: TransportOptions{RoundTripper: rt, Timeout: time.Second},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.http.Timeout)
+	assert.IsType(t, &debugTransport{}, client.http.Transport, "debug logger should wrap the transport")
+
+	_, err = client.Login(context.Background(), LoginOptions{DatabaseName: "db", Username: "user", Password: "pass"})
+	require.NoError(t, err)
+	assert.True(t, called, "custom round tripper should be called through the debug logger")
+}
@@ -0,0 +1,75 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ServerVersion is the version of an Odoo server.
+type ServerVersion struct {
+	// Version is the human-readable version, e.g. "8.0" or "16.0+e".
+	Version string `json:"server_version"`
+	// Info contains the version as `[major, minor, micro, release level, serial]`, e.g. `[16, 0, 0, "final", 0, ""]`.
+	Info []interface{} `json:"server_version_info"`
+}
+
+// ParseServerVersion returns the ServerVersion of the given human-readable version, e.g. "8.0" or "16.0".
+func ParseServerVersion(version string) (ServerVersion, error) {
+	majorStr, _, _ := strings.Cut(version, ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil || major <= 0 {
+		return ServerVersion{}, fmt.Errorf("cannot parse Odoo version %q: expected a version like '8.0' or '16.0'", version)
+	}
+	return ServerVersion{Version: version, Info: []interface{}{float64(major)}}, nil
+}
+
+// Major returns the major version, e.g. 8 for Odoo 8.0.
+// It returns 0 if the version is unknown.
+func (v ServerVersion) Major() int {
+	if len(v.Info) > 0 {
+		if major, ok := v.Info[0].(float64); ok {
+			return int(major)
+		}
+	}
+	major, _ := strconv.Atoi(strings.SplitN(v.Version, ".", 2)[0])
+	return major
+}
+
+// String implements fmt.Stringer.
+func (v ServerVersion) String() string {
+	if v.Version == "" {
+		return "unknown"
+	}
+	return v.Version
+}
+
+// FetchVersion returns the version of the Odoo server.
+// The version is public information, no login is required.
+func (c *Client) FetchVersion(ctx context.Context) (ServerVersion, error) {
+	body, err := NewJSONRPCRequest(map[string]interface{}{}).Encode()
+	if err != nil {
+		return ServerVersion{}, newEncodingRequestError(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint("/web/webclient/version_info"), body)
+	if err != nil {
+		return ServerVersion{}, newCreatingRequestError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return ServerVersion{}, fmt.Errorf("version: sending HTTP request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ServerVersion{}, fmt.Errorf("version: expected HTTP status 200 OK, got %s", res.Status)
+	}
+	version := ServerVersion{}
+	if err := DecodeResult(res.Body, &version); err != nil {
+		return ServerVersion{}, fmt.Errorf("version: %w", newDecodingResultError(err))
+	}
+	return version, nil
+}
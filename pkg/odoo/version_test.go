@@ -0,0 +1,86 @@
+package odoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := map[string]struct {
+		givenVersion  string
+		expectedMajor int
+		expectedError string
+	}{
+		"GivenOdoo8_ThenReturnMajor": {
+			givenVersion:  "8.0",
+			expectedMajor: 8,
+		},
+		"GivenEnterpriseEdition_ThenReturnMajor": {
+			givenVersion:  "16.0+e",
+			expectedMajor: 16,
+		},
+		"GivenInvalidVersion_ThenReturnError": {
+			givenVersion:  "latest",
+			expectedError: `cannot parse Odoo version "latest": expected a version like '8.0' or '16.0'`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := ParseServerVersion(tc.givenVersion)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMajor, result.Major())
+			assert.Equal(t, tc.givenVersion, result.String())
+		})
+	}
+}
+
+func TestServerVersion_Major(t *testing.T) {
+	tests := map[string]struct {
+		givenVersion  ServerVersion
+		expectedMajor int
+	}{
+		"GivenVersionInfo_ThenPreferInfo": {
+			givenVersion:  ServerVersion{Version: "saas~15.2", Info: []interface{}{15.0, 2.0, 0.0, "final", 0.0, ""}},
+			expectedMajor: 15,
+		},
+		"GivenOnlyVersion_ThenParseVersion": {
+			givenVersion:  ServerVersion{Version: "12.0"},
+			expectedMajor: 12,
+		},
+		"GivenEmptyVersion_ThenReturnZero": {
+			givenVersion:  ServerVersion{},
+			expectedMajor: 0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMajor, tc.givenVersion.Major())
+		})
+	}
+}
+
+func TestClient_FetchVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/web/webclient/version_info", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"server_version":"16.0+e","server_version_info":[16,0,0,"final",0,"e"],"server_serie":"16.0","protocol_version":1}}`))
+	}))
+	defer server.Close()
+	client, err := NewClient(server.URL, ClientOptions{})
+	require.NoError(t, err)
+
+	result, err := client.FetchVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "16.0+e", result.String())
+	assert.Equal(t, 16, result.Major())
+}
@@ -0,0 +1,293 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// encodeXMLRPCCall encodes an XML-RPC method call with the given params.
+//
+// The params are converted to JSON first and then to XML-RPC, so that the JSON struct tags and json.Marshaler implementations of the models apply for both protocols.
+// JSON numbers without fraction are encoded as <int>, other numbers as <double>.
+// Object keys are sorted to produce deterministic requests.
+func encodeXMLRPCCall(method string, params ...interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	if err := xml.EscapeText(buf, []byte(method)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</methodName><params>")
+	for _, param := range params {
+		generic, err := toGenericJSON(param)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode param: %w", err)
+		}
+		buf.WriteString("<param>")
+		if err := encodeXMLRPCValue(buf, generic); err != nil {
+			return nil, err
+		}
+		buf.WriteString("</param>")
+	}
+	buf.WriteString("</params></methodCall>")
+	return buf.Bytes(), nil
+}
+
+// toGenericJSON converts v to its generic JSON representation (maps, slices, json.Number, string, bool and nil).
+func toGenericJSON(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic interface{}
+	err = dec.Decode(&generic)
+	return generic, err
+}
+
+func encodeXMLRPCValue(buf *bytes.Buffer, v interface{}) error {
+	buf.WriteString("<value>")
+	switch value := v.(type) {
+	case nil:
+		buf.WriteString("<nil/>")
+	case bool:
+		if value {
+			buf.WriteString("<boolean>1</boolean>")
+		} else {
+			buf.WriteString("<boolean>0</boolean>")
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			buf.WriteString("<int>" + strconv.FormatInt(i, 10) + "</int>")
+		} else {
+			buf.WriteString("<double>" + value.String() + "</double>")
+		}
+	case string:
+		buf.WriteString("<string>")
+		if err := xml.EscapeText(buf, []byte(value)); err != nil {
+			return err
+		}
+		buf.WriteString("</string>")
+	case []interface{}:
+		buf.WriteString("<array><data>")
+		for _, item := range value {
+			if err := encodeXMLRPCValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array>")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteString("<struct>")
+		for _, key := range keys {
+			buf.WriteString("<member><name>")
+			if err := xml.EscapeText(buf, []byte(key)); err != nil {
+				return err
+			}
+			buf.WriteString("</name>")
+			if err := encodeXMLRPCValue(buf, value[key]); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct>")
+	default:
+		return fmt.Errorf("cannot encode %T as XML-RPC value", v)
+	}
+	buf.WriteString("</value>")
+	return nil
+}
+
+// decodeXMLRPCResponse decodes an XML-RPC method response and unmarshals the result into the given pointer.
+// Like in encodeXMLRPCCall, the result is converted through JSON, so that the json.Unmarshaler implementations of the models apply.
+// An *RPCError is returned if the response contains a fault.
+func decodeXMLRPCResponse(r io.Reader, into interface{}) error {
+	result, err := parseXMLRPCResponse(r)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("cannot convert result: %w", err)
+	}
+	return json.Unmarshal(b, into)
+}
+
+func parseXMLRPCResponse(r io.Reader) (interface{}, error) {
+	dec := xml.NewDecoder(r)
+	isFault := false
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("xmlrpc: response without value")
+			}
+			return nil, fmt.Errorf("xmlrpc: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "fault":
+			isFault = true
+		case "value":
+			value, err := decodeXMLRPCValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			if isFault {
+				return nil, newXMLRPCFault(value)
+			}
+			return value, nil
+		}
+	}
+}
+
+// newXMLRPCFault converts an XML-RPC fault.
+// Odoo puts the whole traceback into the "faultCode", of which the last line contains the exception.
+func newXMLRPCFault(value interface{}) *RPCError {
+	members, _ := value.(map[string]interface{})
+	traceback := strings.TrimSpace(fmt.Sprint(members["faultCode"]))
+	rpcErr := &RPCError{Debug: traceback}
+	if msg, ok := members["faultString"].(string); ok {
+		rpcErr.Message = msg
+	}
+	lines := strings.Split(traceback, "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if name, _, found := strings.Cut(last, ":"); found && !strings.Contains(name, " ") {
+		rpcErr.Name = name
+	} else if rpcErr.Message == "" {
+		rpcErr.Message = last
+	}
+	return rpcErr
+}
+
+// decodeXMLRPCValue decodes the content of a <value> element, whose start element has already been consumed.
+func decodeXMLRPCValue(dec *xml.Decoder) (interface{}, error) {
+	var text strings.Builder
+	var value interface{}
+	typed := false
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			typed = true
+			value, err = decodeXMLRPCTypedValue(dec, t)
+			if err != nil {
+				return nil, err
+			}
+		case xml.EndElement:
+			if !typed {
+				// a value without type element is a string
+				return text.String(), nil
+			}
+			return value, nil
+		}
+	}
+}
+
+func decodeXMLRPCTypedValue(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "array":
+		return decodeXMLRPCArray(dec)
+	case "struct":
+		return decodeXMLRPCStruct(dec)
+	case "nil":
+		return nil, dec.Skip()
+	}
+	var text string
+	if err := dec.DecodeElement(&text, &start); err != nil {
+		return nil, fmt.Errorf("xmlrpc: %w", err)
+	}
+	switch start.Name.Local {
+	case "int", "i4", "i8":
+		i, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: invalid integer: %w", err)
+		}
+		return i, nil
+	case "double":
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: invalid double: %w", err)
+		}
+		return f, nil
+	case "boolean":
+		return strings.TrimSpace(text) == "1", nil
+	case "string", "dateTime.iso8601", "base64":
+		return text, nil
+	}
+	return nil, fmt.Errorf("xmlrpc: unsupported type %q", start.Name.Local)
+}
+
+func decodeXMLRPCArray(dec *xml.Decoder) (interface{}, error) {
+	items := make([]interface{}, 0)
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "value" {
+				item, err := decodeXMLRPCValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "array" {
+				return items, nil
+			}
+		}
+	}
+}
+
+func decodeXMLRPCStruct(dec *xml.Decoder) (interface{}, error) {
+	members := map[string]interface{}{}
+	name := ""
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "name":
+				if err := dec.DecodeElement(&name, &t); err != nil {
+					return nil, fmt.Errorf("xmlrpc: %w", err)
+				}
+			case "value":
+				member, err := decodeXMLRPCValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				members[name] = member
+			}
+		case xml.EndElement:
+			if t.Name.Local == "struct" {
+				return members, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+)
+
+// XMLRPCSession implements QueryExecutor using the XML-RPC API of Odoo (`/xmlrpc/2/common` and `/xmlrpc/2/object`).
+// Unlike Session, it doesn't depend on a session cookie: each call is authenticated with the user ID and password.
+type XMLRPCSession struct {
+	externalAPI
+}
+
+// LoginXMLRPC authenticates the user against the XML-RPC API of Odoo.
+// It returns ErrInvalidCredentials if the credentials were wrong.
+func (c *Client) LoginXMLRPC(ctx context.Context, options LoginOptions) (*XMLRPCSession, error) {
+	secret := options.Password
+	if options.APIKey != "" {
+		secret = options.APIKey
+	}
+	api, err := authenticateExternal(ctx, c.callXMLRPCService, c.retryPolicy, options.DatabaseName, options.Username, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &XMLRPCSession{externalAPI: api}, nil
+}
+
+// callXMLRPCService implements externalCall.
+func (c *Client) callXMLRPCService(ctx context.Context, service, method string, into interface{}, args ...interface{}) error {
+	return c.callXMLRPC(ctx, "/xmlrpc/2/"+service, method, into, args...)
+}
+
+func (c *Client) callXMLRPC(ctx context.Context, path, method string, into interface{}, params ...interface{}) error {
+	body, err := encodeXMLRPCCall(method, params...)
+	if err != nil {
+		return newEncodingRequestError(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(path), bytes.NewReader(body))
+	if err != nil {
+		return newCreatingRequestError(err)
+	}
+	req.Header.Set("content-type", "text/xml")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return &networkError{err: err}
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return &HTTPStatusError{StatusCode: res.StatusCode, Status: res.Status}
+	}
+	if err := decodeXMLRPCResponse(res.Body, into); err != nil {
+		return newDecodingResultError(err)
+	}
+	return nil
+}
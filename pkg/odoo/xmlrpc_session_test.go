@@ -0,0 +1,208 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+func newXMLRPCMock(t *testing.T, handle func(method string, params []interface{}) string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, params := parseXMLRPCCall(t, r.Body)
+		switch r.URL.Path {
+		case "/xmlrpc/2/common":
+			assert.Equal(t, "authenticate", method)
+		case "/xmlrpc/2/object":
+			assert.Equal(t, "execute_kw", method)
+			assert.Equal(t, []interface{}{"TestDB", int64(7), "secret"}, params[:3], "credentials")
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("content-type", "text/xml")
+		_, _ = fmt.Fprintf(w, `<?xml version='1.0'?><methodResponse><params><param><value>%s</value></param></params></methodResponse>`, handle(method, params))
+	}))
+}
+
+func newXMLRPCTestSession(t *testing.T, url string) *XMLRPCSession {
+	client, err := NewClient(url, ClientOptions{Protocol: ProtocolXMLRPC})
+	require.NoError(t, err)
+	querier, err := client.Connect(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	require.IsType(t, &XMLRPCSession{}, querier)
+	return querier.(*XMLRPCSession)
+}
+
+func TestXMLRPCSession_Login(t *testing.T) {
+	tests := map[string]struct {
+		givenResponse string
+		expectedUID   int
+		expectedError error
+	}{
+		"GivenValidCredentials_ThenReturnSession": {
+			givenResponse: "<int>7</int>",
+			expectedUID:   7,
+		},
+		"GivenInvalidCredentials_ThenReturnError": {
+			givenResponse: "<boolean>0</boolean>",
+			expectedError: ErrInvalidCredentials,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			odooMock := newXMLRPCMock(t, func(method string, params []interface{}) string {
+				assert.Equal(t, []interface{}{"TestDB", "jane", "secret", map[string]interface{}{}}, params)
+				return tc.givenResponse
+			})
+			defer odooMock.Close()
+			client, err := NewClient(odooMock.URL, ClientOptions{})
+			require.NoError(t, err)
+			session, err := client.LoginXMLRPC(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUID, session.UID)
+		})
+	}
+}
+
+func TestXMLRPCSession_SearchGenericModel(t *testing.T) {
+	calls := []string{}
+	odooMock := newXMLRPCMock(t, func(method string, params []interface{}) string {
+		if method == "authenticate" {
+			return "<int>7</int>"
+		}
+		calls = append(calls, params[4].(string))
+		assert.Equal(t, "hr.employee", params[3])
+		assert.Equal(t, []interface{}{[]interface{}{[]interface{}{"user_id", "=", int64(1)}}}, params[5], "args")
+		if params[4] == "search_count" {
+			return "<int>3</int>"
+		}
+		assert.Equal(t, map[string]interface{}{
+			"fields": []interface{}{"name"},
+			"limit":  int64(1),
+			"order":  "id ASC",
+		}, params[6], "kwargs")
+		return `<array><data><value><struct>
+<member><name>id</name><value><int>2</int></value></member>
+<member><name>name</name><value><string>Jane Doe</string></value></member>
+</struct></value></data></array>`
+	})
+	defer odooMock.Close()
+	session := newXMLRPCTestSession(t, odooMock.URL)
+
+	result := struct {
+		Length  int `json:"length"`
+		Records []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"records"`
+	}{}
+	err := session.SearchGenericModel(context.Background(), SearchReadModel{
+		Model:      "hr.employee",
+		Domain:     domain.New(domain.Eq("user_id", 1)),
+		Fields:     []string{"name"},
+		Limit:      1,
+		Sort:       "id ASC",
+		CountTotal: true,
+	}, &result)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Length)
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, 2, result.Records[0].ID)
+	assert.Equal(t, "Jane Doe", result.Records[0].Name)
+	assert.Equal(t, []string{"search_read", "search_count"}, calls)
+
+	t.Run("GivenNoCountTotal_ThenSkipSearchCount", func(t *testing.T) {
+		calls = []string{}
+		err := session.SearchGenericModel(context.Background(), SearchReadModel{
+			Model:  "hr.employee",
+			Domain: domain.New(domain.Eq("user_id", 1)),
+			Fields: []string{"name"},
+			Limit:  1,
+			Sort:   "id ASC",
+		}, &result)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Length)
+		assert.Equal(t, []string{"search_read"}, calls)
+	})
+}
+
+func TestXMLRPCSession_WriteMethods(t *testing.T) {
+	odooMock := newXMLRPCMock(t, func(method string, params []interface{}) string {
+		if method == "authenticate" {
+			return "<int>7</int>"
+		}
+		assert.Equal(t, "hr.attendance", params[3])
+		assert.Equal(t, map[string]interface{}{}, params[6], "kwargs")
+		switch params[4] {
+		case "create":
+			assert.Equal(t, []interface{}{map[string]interface{}{"name": "2022-03-04 07:00:00"}}, params[5])
+			return "<int>12</int>"
+		case "write":
+			assert.Equal(t, []interface{}{[]interface{}{int64(12)}, map[string]interface{}{"action": "sign_out"}}, params[5])
+			return "<boolean>1</boolean>"
+		case "unlink":
+			assert.Equal(t, []interface{}{[]interface{}{int64(12)}}, params[5])
+			return "<boolean>1</boolean>"
+		}
+		t.Errorf("unexpected method: %v", params[4])
+		return ""
+	})
+	defer odooMock.Close()
+	session := newXMLRPCTestSession(t, odooMock.URL)
+	ctx := context.Background()
+
+	id, err := session.CreateGenericModel(ctx, "hr.attendance", map[string]interface{}{"name": "2022-03-04 07:00:00"})
+	require.NoError(t, err)
+	assert.Equal(t, 12, id)
+	require.NoError(t, session.UpdateGenericModel(ctx, "hr.attendance", id, map[string]interface{}{"action": "sign_out"}))
+	require.NoError(t, session.DeleteGenericModel(ctx, "hr.attendance", []int{id}))
+}
+
+func TestXMLRPCSession_Retry(t *testing.T) {
+	numCalls, failures := 0, 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, _ := parseXMLRPCCall(t, r.Body)
+		value := "<int>7</int>"
+		if method == "execute_kw" {
+			numCalls++
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			value = "<array><data></data></array>"
+		}
+		w.Header().Set("content-type", "text/xml")
+		_, _ = fmt.Fprintf(w, `<?xml version='1.0'?><methodResponse><params><param><value>%s</value></param></params></methodResponse>`, value)
+	}))
+	defer odooMock.Close()
+	client, err := NewClient(odooMock.URL, ClientOptions{Protocol: ProtocolXMLRPC, RetryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}})
+	require.NoError(t, err)
+	session, err := client.LoginXMLRPC(context.Background(), LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+
+	t.Run("GivenBadGateway_WhenSearching_ThenRetry", func(t *testing.T) {
+		numCalls, failures = 0, 1
+		err := session.SearchGenericModel(context.Background(), SearchReadModel{Model: "hr.employee"}, &struct{}{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, numCalls)
+	})
+	t.Run("GivenBadGateway_WhenWriting_ThenReturnStatusError", func(t *testing.T) {
+		numCalls, failures = 0, 1
+		err := session.UpdateGenericModel(context.Background(), "hr.employee", 2, map[string]interface{}{})
+		statusErr := &HTTPStatusError{}
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+		assert.Equal(t, 1, numCalls, "writes aren't retried")
+	})
+}
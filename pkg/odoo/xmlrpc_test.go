@@ -0,0 +1,130 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/domain"
+)
+
+func TestEncodeXMLRPCCall(t *testing.T) {
+	tests := map[string]struct {
+		givenParams  []interface{}
+		expectedBody string
+	}{
+		"GivenScalars_ThenEncodeTypes": {
+			givenParams:  []interface{}{"db & co", 7, 1.5, true, nil},
+			expectedBody: "<param><value><string>db &amp; co</string></value></param><param><value><int>7</int></value></param><param><value><double>1.5</double></value></param><param><value><boolean>1</boolean></value></param><param><value><nil/></value></param>",
+		},
+		"GivenMap_ThenEncodeStructWithSortedMembers": {
+			givenParams:  []interface{}{map[string]interface{}{"name": "Jane", "age": 42}},
+			expectedBody: "<param><value><struct><member><name>age</name><value><int>42</int></value></member><member><name>name</name><value><string>Jane</string></value></member></struct></value></param>",
+		},
+		"GivenDomain_ThenEncodeArrays": {
+			givenParams:  []interface{}{domain.New(domain.Eq("id", 2))},
+			expectedBody: "<param><value><array><data><value><array><data><value><string>id</string></value><value><string>=</string></value><value><int>2</int></value></data></array></value></data></array></value></param>",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := encodeXMLRPCCall("execute_kw", tc.givenParams...)
+			require.NoError(t, err)
+			assert.Equal(t, xml.Header+"<methodCall><methodName>execute_kw</methodName><params>"+tc.expectedBody+"</params></methodCall>", string(b))
+		})
+	}
+}
+
+func TestDecodeXMLRPCResponse(t *testing.T) {
+	tests := map[string]struct {
+		givenBody      string
+		expectedResult interface{}
+		expectedError  string
+	}{
+		"GivenStructWithMany2One_ThenDecodeAsJSONTypes": {
+			givenBody: `<?xml version='1.0'?>
+<methodResponse><params><param><value><array><data>
+<value><struct>
+<member><name>id</name><value><int>2</int></value></member>
+<member><name>name</name><value><string>Jane &amp; Doe</string></value></member>
+<member><name>user_id</name><value><array><data><value><int>1</int></value><value><string>Jane</string></value></data></array></value></member>
+<member><name>work_email</name><value><boolean>0</boolean></value></member>
+<member><name>note</name><value>untyped</value></member>
+</struct></value>
+</data></array></value></param></params></methodResponse>`,
+			expectedResult: []interface{}{map[string]interface{}{
+				"id":         float64(2),
+				"name":       "Jane & Doe",
+				"user_id":    []interface{}{float64(1), "Jane"},
+				"work_email": false,
+				"note":       "untyped",
+			}},
+		},
+		"GivenFault_ThenReturnFault": {
+			givenBody: `<?xml version='1.0'?>
+<methodResponse><fault><value><struct>
+<member><name>faultCode</name><value><string>Traceback (most recent call last):
+  File "odoo/service/model.py", line 1
+AccessDenied: Access Denied</string></value></member>
+<member><name>faultString</name><value><string>Access Denied</string></value></member>
+</struct></value></fault></methodResponse>`,
+			expectedError: "AccessDenied: Access Denied",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var result interface{}
+			err := decodeXMLRPCResponse(strings.NewReader(tc.givenBody), &result)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				assert.True(t, IsAccessDenied(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+// parseXMLRPCCall decodes the method name and params of a method call.
+func parseXMLRPCCall(t *testing.T, r io.Reader) (string, []interface{}) {
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	method := ""
+	params := make([]interface{}, 0)
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return method, params
+		}
+		require.NoError(t, err)
+		if start, ok := token.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "methodName":
+				require.NoError(t, dec.DecodeElement(&method, &start))
+			case "value":
+				value, err := decodeXMLRPCValue(dec)
+				require.NoError(t, err)
+				params = append(params, value)
+			}
+		}
+	}
+}
+
+func TestRedactor_XMLRPCPassword(t *testing.T) {
+	for _, method := range []string{"authenticate", "execute_kw"} {
+		t.Run(method, func(t *testing.T) {
+			b, err := encodeXMLRPCCall(method, "TestDB", "jane", "secret", map[string]interface{}{})
+			require.NoError(t, err)
+			redacted := string(newRedactor().redactRequest(b))
+			assert.NotContains(t, redacted, "secret")
+			assert.Contains(t, redacted, "<string>[confidential]</string>")
+			assert.Contains(t, redacted, "<string>jane</string>")
+		})
+	}
+}
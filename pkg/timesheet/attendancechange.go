@@ -0,0 +1,63 @@
+package timesheet
+
+import (
+	"fmt"
+
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+const (
+	// OperationCreate adds a new attendance.
+	OperationCreate = "create"
+	// OperationUpdate changes the time and reason of an existing attendance.
+	OperationUpdate = "update"
+	// OperationDelete removes an existing attendance.
+	OperationDelete = "delete"
+)
+
+// AttendanceChange is a change of a single attendance.
+type AttendanceChange struct {
+	// Operation is one of OperationCreate, OperationUpdate or OperationDelete.
+	Operation string
+	// Attendance is the new attendance for OperationCreate and OperationUpdate, or the attendance to delete for OperationDelete.
+	// Attendances are identified by ID and action, since attendances of Odoo 10 and later share the ID of their shift.
+	Attendance model.Attendance
+}
+
+// Apply returns a copy of the given list with the change applied.
+func (c AttendanceChange) Apply(list model.AttendanceList) (model.AttendanceList, error) {
+	result := model.AttendanceList{Items: make([]model.Attendance, 0, len(list.Items)+1)}
+	found := false
+	for _, attendance := range list.Items {
+		if attendance.ID != c.Attendance.ID || attendance.Action != c.Attendance.Action || c.Operation == OperationCreate {
+			result.Items = append(result.Items, attendance)
+			continue
+		}
+		found = true
+		if c.Operation == OperationUpdate {
+			result.Items = append(result.Items, c.Attendance)
+		}
+	}
+	if c.Operation == OperationCreate {
+		result.Items = append(result.Items, c.Attendance)
+	} else if !found {
+		return result, fmt.Errorf("%s with ID %d not found", c.Attendance.Action, c.Attendance.ID)
+	}
+	result.Sort()
+	return result, nil
+}
+
+// String returns a human-readable description of the change.
+func (c AttendanceChange) String() string {
+	reason := ""
+	if c.Attendance.Reason.String() != "" {
+		reason = fmt.Sprintf(" (%s)", c.Attendance.Reason)
+	}
+	switch c.Operation {
+	case OperationCreate:
+		return fmt.Sprintf("Add %s at %s%s", c.Attendance.Action, c.Attendance.DateTime.Format("15:04"), reason)
+	case OperationUpdate:
+		return fmt.Sprintf("Change %s to %s%s", c.Attendance.Action, c.Attendance.DateTime.Format("15:04"), reason)
+	}
+	return fmt.Sprintf("Delete %s", c.Attendance.Action)
+}
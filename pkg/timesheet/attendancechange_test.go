@@ -0,0 +1,55 @@
+package timesheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+func TestAttendanceChange_Apply(t *testing.T) {
+	signIn := model.Attendance{ID: 1, Action: model.ActionSignIn, DateTime: odoo.NewDate(2022, time.March, 2, 8, 0, 0, zurichTZ)}
+	signOut := model.Attendance{ID: 2, Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 17, 0, 0, zurichTZ)}
+	tests := map[string]struct {
+		givenChange         AttendanceChange
+		expectedAttendances []model.Attendance
+		expectedError       string
+	}{
+		"GivenCreate_ThenAddSorted": {
+			givenChange: AttendanceChange{Operation: OperationCreate, Attendance: model.Attendance{
+				Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 12, 0, 0, zurichTZ),
+			}},
+			expectedAttendances: []model.Attendance{signIn, {Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 12, 0, 0, zurichTZ)}, signOut},
+		},
+		"GivenUpdate_ThenReplaceAttendance": {
+			givenChange: AttendanceChange{Operation: OperationUpdate, Attendance: model.Attendance{
+				ID: 2, Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 18, 0, 0, zurichTZ),
+			}},
+			expectedAttendances: []model.Attendance{signIn, {ID: 2, Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 18, 0, 0, zurichTZ)}},
+		},
+		"GivenDelete_ThenRemoveAttendance": {
+			givenChange:         AttendanceChange{Operation: OperationDelete, Attendance: model.Attendance{ID: 2, Action: model.ActionSignOut}},
+			expectedAttendances: []model.Attendance{signIn},
+		},
+		"GivenDeleteWithOtherAction_ThenReturnError": {
+			givenChange:   AttendanceChange{Operation: OperationDelete, Attendance: model.Attendance{ID: 2, Action: model.ActionSignIn}},
+			expectedError: "sign_in with ID 2 not found",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := model.AttendanceList{Items: []model.Attendance{signIn, signOut}}
+			result, err := tc.givenChange.Apply(given)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAttendances, result.Items)
+			assert.Len(t, given.Items, 2, "given list should not be modified")
+		})
+	}
+}
@@ -1,11 +1,8 @@
 package timesheet
 
 import (
-	"fmt"
+	"math"
 	"time"
-
-	"github.com/vshn/odootools/pkg/odoo"
-	"github.com/vshn/odootools/pkg/odoo/model"
 )
 
 type DailySummary struct {
@@ -14,18 +11,24 @@ type DailySummary struct {
 	Shifts   []AttendanceShift
 	Absences []AbsenceBlock
 	FTERatio float64
+	// Rules are the business rules that apply to the date.
+	// The rules of DefaultRuleSet are used if nil.
+	Rules *Rules
 }
 
 type OvertimeSummary struct {
+	// RegularWorkingTime is the time of shifts that count as work without multiplier.
 	RegularWorkingTime time.Duration
-	SickLeaveTime      time.Duration
-	AuthoritiesTime    time.Duration
-	OutOfOfficeTime    time.Duration
-	DailyMax           time.Duration
-	PublicServiceTime  time.Duration
+	// OutOfOfficeTime is the actual time of shifts that count as work with a multiplier, e.g. outside office hours.
+	OutOfOfficeTime time.Duration
+	// WeightedOutOfOfficeTime is OutOfOfficeTime with the multipliers applied.
+	WeightedOutOfOfficeTime time.Duration
+	// UncappedExcusedTime is the time of shifts with excused reasons like sick leave, with the multipliers applied.
+	UncappedExcusedTime time.Duration
+	DailyMax            time.Duration
 }
 
-// NewDailySummary creates a new instance.
+// NewDailySummary creates a new instance with the rules of DefaultRuleSet.
 // The fteRatio is the percentage (input a value between 0..1) of the employee and is used to calculate the daily maximum hours an employee should work.
 // Date is expected to be in a localized timezone.
 func NewDailySummary(fteRatio float64, date time.Time) *DailySummary {
@@ -34,12 +37,20 @@ func NewDailySummary(fteRatio float64, date time.Time) *DailySummary {
 		Date:     date,
 		Absences: []AbsenceBlock{},
 		Shifts:   []AttendanceShift{},
+		Rules:    DefaultRuleSet.RulesFor(date),
+	}
+}
+
+// rules returns DailySummary.Rules or the rules of DefaultRuleSet if unset.
+func (s *DailySummary) rules() *Rules {
+	if s.Rules != nil {
+		return s.Rules
 	}
+	return DefaultRuleSet.RulesFor(s.Date)
 }
 
 // addAbsenceBlock adds the given block to the existing absences.
 func (s *DailySummary) addAbsenceBlock(block AbsenceBlock) {
-	// At VSHN, currently only full-day absences are possible, so no need to check for starting and ending time.
 	s.Absences = append(s.Absences, block)
 }
 
@@ -47,7 +58,7 @@ func (s *DailySummary) addAbsenceBlock(block AbsenceBlock) {
 // If returned duration is positive, then the employee did overtime and undertime if duration is negative.
 //
 // The overtime is then calculated according to these business rules:
-//   - Outside office hours are multiplied by 1.5 (as a compensation)
+//   - Working hours are multiplied with the multiplier of their reason, e.g. 1.5 for outside office hours (as a compensation)
 //   - Excused hours like sick leave, authorities or public service can be used to "fill up" the daily theoretical maximum if the working hours are less than said maximum.
 //     However, there's no overtime possible using excused hours
 //   - If the working hours exceed the theoretical daily maximum, then the excused hours are basically ignored.
@@ -56,16 +67,11 @@ func (s *DailySummary) CalculateOvertimeSummary() OvertimeSummary {
 	os := OvertimeSummary{}
 	dailyMax := s.calculateDailyMax() - s.CalculateAbsenceTime()
 	os.DailyMax = dailyMax
-	s.calculateWorkingTime(&os)
-	s.calculateExcusedTime(&os)
+	s.calculateShiftTimes(&os)
 	excused := os.ExcusedTime()
 	worked := os.WorkingTime()
 	if excused < 0 || worked < 0 || excused >= 24*time.Hour || worked >= 36*time.Hour { // attendances are incorrect
-		os.PublicServiceTime = 0
-		os.AuthoritiesTime = 0
-		os.SickLeaveTime = 0
-		os.OutOfOfficeTime = 0
-		os.RegularWorkingTime = 0
+		os = OvertimeSummary{DailyMax: dailyMax}
 	}
 
 	return os
@@ -85,15 +91,14 @@ func (s OvertimeSummary) Overtime() time.Duration {
 	return workingTime + excusedTime - s.DailyMax
 }
 
-// WorkingTime is the sum of OutOfOfficeTime (multiplied with 1.5) and RegularWorkingTime.
+// WorkingTime is the sum of WeightedOutOfOfficeTime and RegularWorkingTime.
 func (s OvertimeSummary) WorkingTime() time.Duration {
-	overtime := 1.5 * float64(s.OutOfOfficeTime)
-	return s.RegularWorkingTime + time.Duration(overtime)
+	return s.RegularWorkingTime + s.WeightedOutOfOfficeTime
 }
 
-// ExcusedTime returns the sum of AuthoritiesTime, PublicServiceTime and SickLeaveTime, but it can't exceed DailyMax.
+// ExcusedTime returns UncappedExcusedTime, but it can't exceed DailyMax.
 func (s OvertimeSummary) ExcusedTime() time.Duration {
-	sum := s.AuthoritiesTime + s.PublicServiceTime + s.SickLeaveTime
+	sum := s.UncappedExcusedTime
 	if sum >= s.DailyMax {
 		return s.DailyMax
 	}
@@ -103,98 +108,92 @@ func (s OvertimeSummary) ExcusedTime() time.Duration {
 // ValidateTimesheetEntries checks if the DailySummary has invalid or incomplete shifts.
 // A shift is invalid in the following conditions:
 //   - There is no sign_in action before any sign_out
-//   - There is no sign_out action after any sign_in
+//   - There is no sign_out action after any sign_in, e.g. 2 consecutive sign_ins
+//   - There is a duplicate sign_in or sign_out with the same timestamp
+//   - It overlaps with other shifts
 //   - Start and end of a shift are the same time (duration = 0s)
 //   - Reasons of start and end of a shift are different
 //   - Duration of all shifts exceeds 24h (it should be split over multiple days)
+//
+// All problems are returned as FindingList, warnings are ignored.
+// See Findings for warnings and candidate corrections.
 func (s *DailySummary) ValidateTimesheetEntries() error {
-	day := s.Date.Format(odoo.DateFormat)
-	totalDuration := time.Duration(0)
-	for _, shift := range s.Shifts {
-		shiftDuration := shift.Duration()
-		if shiftDuration == 0 {
-			return NewValidationError(s.Date, fmt.Errorf("shift start and end times cannot be the same for %s: %s", day, shift.Start.DateTime.Format(odoo.TimeFormat)))
-		}
-		if !shift.Start.DateTime.IsZero() && shift.End.DateTime.IsZero() {
-			return NewValidationError(s.Date, fmt.Errorf("no %s detected for %s after %s", model.ActionSignOut, day, shift.Start.DateTime.Format(odoo.TimeFormat)))
-		}
-		if !shift.End.DateTime.IsZero() && shift.Start.DateTime.IsZero() {
-			return NewValidationError(s.Date, fmt.Errorf("no %s detected for %s before %s", model.ActionSignIn, day, shift.End.DateTime.Format(odoo.TimeFormat)))
-		}
-		if shift.Start.Reason.String() != shift.End.Reason.String() {
-			return NewValidationError(s.Date, fmt.Errorf("the reasons for shift %s and %s should be equal: start %s (%s), end %s (%s)",
-				model.ActionSignIn, model.ActionSignOut, shift.Start.DateTime.Format(odoo.TimeFormat), shift.Start.Reason, shift.End.DateTime.Format(odoo.TimeFormat), shift.End.Reason))
+	errs := make(FindingList, 0)
+	for _, finding := range s.Findings() {
+		if finding.Severity == SeverityError {
+			errs = append(errs, finding)
 		}
-		totalDuration += shiftDuration
 	}
-	if totalDuration > 24*time.Hour {
-		// this shouldn't be possible in theory, but maybe someone forgot to sign out.
-		return NewValidationError(s.Date, fmt.Errorf("duration of all shifts for %s cannot exceed 24h: %s", day, totalDuration))
+	if len(errs) == 0 {
+		return nil
 	}
-	return nil
+	return NewValidationError(s.Date, errs)
 }
 
 // calculateDailyMax returns the theoretical amount of hours that an employee should work on this day.
-//   - It returns 0 for weekend days.
-//   - It returns 8.5 hours multiplied by FTE ratio for days in 2020 and earlier.
-//   - It returns 8.0 hours multiplied by FTE ratio for days in 2021 and later.
+// It returns the weekly hours of the rules distributed over the working days, multiplied by FTE ratio, or 0 if it's not a working day.
 func (s *DailySummary) calculateDailyMax() time.Duration {
-	if s.IsWeekend() {
-		return 0
-	}
-	if s.Date.Year() < 2021 {
-		// VSHN switched from 42h-a-week to 40h-a-week on 1st of January 2021.
-		return time.Duration(8.5 * s.FTERatio * float64(time.Hour))
-	}
-	return time.Duration(8 * s.FTERatio * float64(time.Hour))
+	return s.rules().DailyMax(s.Date.Weekday(), s.FTERatio)
 }
 
-// calculateWorkingTime accumulates all working hours from that day.
-func (s *DailySummary) calculateWorkingTime(o *OvertimeSummary) {
-	for _, shift := range s.Shifts {
-		if isInvalidShift(shift) {
+// calculateShiftTimes accumulates the working and excused hours from that day according to the reasons of the shifts.
+// Shifts with reasons that aren't mapped by the rules are ignored.
+// Overlapping shifts are ignored as well, including the complete ones, until they're corrected.
+func (s *DailySummary) calculateShiftTimes(o *OvertimeSummary) {
+	rules := s.rules()
+	overlapping := s.overlappingShifts()
+	for i, shift := range s.Shifts {
+		if isInvalidShift(shift) || overlapping[i] {
 			continue // invalid attendances for this shift, ignore
 		}
+		reason, found := rules.Reason(shift.Start.Reason.String())
+		if !found {
+			continue
+		}
 		diff := shift.End.DateTime.Sub(shift.Start.DateTime.Time)
-		switch shift.Start.Reason.String() {
-		case "":
+		weighted := time.Duration(reason.Multiplier * float64(diff))
+		switch {
+		case reason.Category == CategoryExcused:
+			o.UncappedExcusedTime += weighted
+		case reason.Multiplier == 1:
 			o.RegularWorkingTime += diff
-		case ReasonOutsideOfficeHours:
+		default:
 			o.OutOfOfficeTime += diff
+			o.WeightedOutOfOfficeTime += weighted
 		}
 	}
 }
 
-// calculateExcusedTime accumulates all hours that are excused in some way (sick leave etc) from that day.
-func (s *DailySummary) calculateExcusedTime(o *OvertimeSummary) {
-	for _, shift := range s.Shifts {
-		if isInvalidShift(shift) {
-			continue // invalid attendances for this shift, ignore
-		}
-		diff := shift.End.DateTime.Sub(shift.Start.DateTime.Time)
-		switch shift.Start.Reason.String() {
-		case ReasonSickLeave:
-			o.SickLeaveTime += diff
-		case ReasonAuthorities:
-			o.AuthoritiesTime += diff
-		case ReasonPublicService:
-			o.PublicServiceTime += diff
+// CalculateAbsenceTime accumulates all absence hours from that day.
+// The absence time doesn't exceed the daily max, e.g. a full-day leave reduces the daily max to 0.
+func (s *DailySummary) CalculateAbsenceTime() time.Duration {
+	total := time.Duration(0)
+	for _, absence := range s.Absences {
+		if s.rules().IsPaidLeave(absence.Reason) {
+			// Odoo treats every type as normal leave, but some may be informational-only, meaning one still has to work, e.g. "Unpaid" at VSHN.
+			total += absence.Duration
 		}
 	}
+	if dailyMax := s.calculateDailyMax(); total > dailyMax {
+		return dailyMax
+	}
+	return total
 }
 
-// CalculateAbsenceTime accumulates all absence hours from that day.
-func (s *DailySummary) CalculateAbsenceTime() time.Duration {
+// CalculateLeaveDays returns the fraction of the day that is covered by "personalized" leaves, see IsHoliday.
+// For example, a 4h leave on a day with 8h daily max counts as 0.5 days.
+func (s *DailySummary) CalculateLeaveDays() float64 {
+	dailyMax := s.calculateDailyMax()
+	if !s.IsHoliday() || dailyMax <= 0 {
+		return 0
+	}
 	total := time.Duration(0)
 	for _, absence := range s.Absences {
-		if absence.Reason != TypeUnpaid {
-			// VSHN specific: Odoo treats "Unpaid" as normal leave, but for VSHN it's informational-only, meaning one still has to work.
-			// For every other type of absence, we add the daily max equivalent.
-
-			total += s.calculateDailyMax()
+		if s.rules().IsPaidLeave(absence.Reason) && absence.Reason != TypePublicHoliday {
+			total += absence.Duration
 		}
 	}
-	return total
+	return math.Min(float64(total)/float64(dailyMax), 1)
 }
 
 // HasAbsences returns true if there are any absences.
@@ -204,16 +203,21 @@ func (s *DailySummary) HasAbsences() bool {
 
 // IsHoliday returns true if there is a "personalized" leave.
 // Public and unpaid holidays return false.
-// If the holiday falls on a weekend, the day is not counted.
+// If the holiday doesn't fall on a working day, the day is not counted.
 func (s *DailySummary) IsHoliday() bool {
 	for _, absence := range s.Absences {
-		if absence.Reason != TypeUnpaid && absence.Reason != TypePublicHoliday {
-			return !s.IsWeekend()
+		if s.rules().IsPaidLeave(absence.Reason) && absence.Reason != TypePublicHoliday {
+			return s.IsWorkingDay()
 		}
 	}
 	return false
 }
 
+// IsWorkingDay returns true if the date falls on a working day of the rules.
+func (s *DailySummary) IsWorkingDay() bool {
+	return s.rules().IsWorkingDay(s.Date.Weekday())
+}
+
 // IsWeekend returns true if the date falls on a Saturday or Sunday.
 func (s *DailySummary) IsWeekend() bool {
 	return s.Date.Weekday() == time.Saturday || s.Date.Weekday() == time.Sunday
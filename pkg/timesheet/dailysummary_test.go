@@ -118,6 +118,16 @@ func TestDailySummary_CalculateOvertime(t *testing.T) {
 			expectedOvertime:    -1 * time.Hour,
 			expectedExcusedTime: 0,
 		},
+		"GivenOverlappingShifts_ThenIgnoreAllOverlappingShifts": {
+			givenShifts: []AttendanceShift{
+				newAttendanceShift(hours(t, weekday, "09:00"), odoo.Date{}, ""),
+				newAttendanceShift(hours(t, weekday, "10:00"), hours(t, weekday, "12:00"), ""),
+				newAttendanceShift(odoo.Date{}, hours(t, weekday, "13:00"), ""),
+				newAttendanceShift(hours(t, weekday, "14:00"), hours(t, weekday, "18:00"), ""),
+			},
+			expectedOvertime:    -4 * time.Hour,
+			expectedExcusedTime: 0,
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -288,6 +298,66 @@ func TestDailySummary_IsHoliday(t *testing.T) {
 	}
 }
 
+func TestDailySummary_CalculateAbsenceTime(t *testing.T) {
+	tests := map[string]struct {
+		givenFteRatio       float64
+		givenAbsences       []AbsenceBlock
+		expectedAbsenceTime time.Duration
+		expectedLeaveDays   float64
+	}{
+		"GivenFullDayLeave_ThenReduceDailyMaxToZero": {
+			givenFteRatio:       1,
+			givenAbsences:       []AbsenceBlock{{Reason: TypeLegalLeavesPrefix, Duration: 24*time.Hour - time.Second}},
+			expectedAbsenceTime: 8 * time.Hour,
+			expectedLeaveDays:   1,
+		},
+		"GivenHalfDayLeave_ThenReduceDailyMaxByHalf": {
+			givenFteRatio:       1,
+			givenAbsences:       []AbsenceBlock{{Reason: TypeLegalLeavesPrefix, Duration: 4 * time.Hour}},
+			expectedAbsenceTime: 4 * time.Hour,
+			expectedLeaveDays:   0.5,
+		},
+		"GivenHourlyLeave_WhenPartTime_ThenCountFractionOfReducedDailyMax": {
+			givenFteRatio:       0.5,
+			givenAbsences:       []AbsenceBlock{{Reason: TypeSpecialOccasions, Duration: time.Hour}},
+			expectedAbsenceTime: time.Hour,
+			expectedLeaveDays:   0.25,
+		},
+		"GivenMultiplePartialLeaves_ThenCapAtDailyMax": {
+			givenFteRatio: 1,
+			givenAbsences: []AbsenceBlock{
+				{Reason: TypeLegalLeavesPrefix, Duration: 5 * time.Hour},
+				{Reason: TypeMilitaryService, Duration: 5 * time.Hour},
+			},
+			expectedAbsenceTime: 8 * time.Hour,
+			expectedLeaveDays:   1,
+		},
+		"GivenHalfDayPublicHoliday_ThenReduceDailyMaxButDontCountAsLeave": {
+			givenFteRatio:       1,
+			givenAbsences:       []AbsenceBlock{{Reason: TypePublicHoliday, Duration: 4 * time.Hour}},
+			expectedAbsenceTime: 4 * time.Hour,
+			expectedLeaveDays:   0,
+		},
+		"GivenUnpaidLeave_ThenIgnore": {
+			givenFteRatio:       1,
+			givenAbsences:       []AbsenceBlock{{Reason: TypeUnpaid, Duration: 4 * time.Hour}},
+			expectedAbsenceTime: 0,
+			expectedLeaveDays:   0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := DailySummary{
+				Date:     odoo.MustParseDate("2021-02-03").In(zurichTZ),
+				FTERatio: tt.givenFteRatio,
+				Absences: tt.givenAbsences,
+			}
+			assert.Equal(t, tt.expectedAbsenceTime, s.CalculateAbsenceTime(), "absence time")
+			assert.InDelta(t, tt.expectedLeaveDays, s.CalculateLeaveDays(), 0.0001, "leave days")
+		})
+	}
+}
+
 func TestDailySummary_ValidateTimesheetEntries(t *testing.T) {
 	tests := map[string]struct {
 		givenShifts   []AttendanceShift
@@ -341,6 +411,22 @@ func TestDailySummary_ValidateTimesheetEntries(t *testing.T) {
 			},
 			expectedError: "duration of all shifts for 2021-01-02 cannot exceed 24h: 24h0m1s",
 		},
+		"Multiple_Problems_ReturnAll": {
+			givenShifts: []AttendanceShift{
+				{End: model.Attendance{DateTime: odoo.NewDate(2021, 01, 02, 7, 0, 0, time.UTC), Action: model.ActionSignOut}},
+				{Start: model.Attendance{DateTime: odoo.NewDate(2021, 01, 02, 8, 0, 0, time.UTC), Action: model.ActionSignIn}},
+			},
+			expectedError: "no sign_in detected for 2021-01-02 before 07:00:00; no sign_out detected for 2021-01-02 after 08:00:00",
+		},
+		"LongShift_NoError": {
+			givenShifts: []AttendanceShift{
+				{
+					Start: model.Attendance{DateTime: odoo.NewDate(2021, 01, 02, 6, 0, 0, time.UTC), Action: model.ActionSignIn},
+					End:   model.Attendance{DateTime: odoo.NewDate(2021, 01, 02, 20, 0, 0, time.UTC), Action: model.ActionSignOut},
+				},
+			},
+			expectedError: "",
+		},
 		"DifferentReasonsInShift": {
 			givenShifts: []AttendanceShift{
 				{
@@ -0,0 +1,410 @@
+package timesheet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+// FindingKind identifies the problem of a Finding.
+type FindingKind string
+
+const (
+	// KindMissingSignOut is a shift that has been started but not ended.
+	KindMissingSignOut FindingKind = "missing_sign_out"
+	// KindMissingSignIn is a shift that has been ended but not started.
+	KindMissingSignIn FindingKind = "missing_sign_in"
+	// KindDuplicateAttendance is an attendance that has the same action and timestamp as the previous one.
+	KindDuplicateAttendance FindingKind = "duplicate_attendance"
+	// KindZeroDuration is a shift that starts and ends at the same time.
+	KindZeroDuration FindingKind = "zero_duration"
+	// KindReasonMismatch is a shift that has different reasons for start and end.
+	KindReasonMismatch FindingKind = "reason_mismatch"
+	// KindExceeds24h is a day on which all shifts together last longer than 24h.
+	KindExceeds24h FindingKind = "exceeds_24h"
+	// KindConsecutiveSignIn is a shift that has been started but is followed by another sign_in instead of a sign_out.
+	KindConsecutiveSignIn FindingKind = "consecutive_sign_in"
+	// KindOverlap are shifts that overlap each other, e.g. if they have been recorded on different devices.
+	KindOverlap FindingKind = "overlap"
+	// KindLongShift is a shift that lasts longer than expected, which is likely a forgotten sign_out.
+	KindLongShift FindingKind = "long_shift"
+)
+
+// Severity defines how a Finding affects the calculation of the day.
+type Severity string
+
+const (
+	// SeverityError is a problem that makes the day invalid, the affected shifts are ignored in the calculation.
+	SeverityError Severity = "error"
+	// SeverityWarning is a suspicious entry that is still included in the calculation.
+	SeverityWarning Severity = "warning"
+)
+
+// longShiftDuration is the duration after which a shift is reported as KindLongShift.
+const longShiftDuration = 12 * time.Hour
+
+// Finding is a problem in the attendances of a day, together with candidate corrections.
+type Finding struct {
+	Kind     FindingKind
+	Severity Severity
+	// AttendanceIDs are the IDs of the attendances causing the problem.
+	AttendanceIDs []int
+	Err           error
+	// Fixes are the suggested corrections.
+	// It's empty if there is no obvious correction.
+	Fixes []Fix
+
+	// shift is the index of the shift of the day that causes the problem, -1 if the problem concerns the whole day.
+	shift int
+}
+
+// FindingList is an error that consists of multiple findings.
+type FindingList []Finding
+
+// Error implements error.
+// It returns the messages of the findings separated by semicolon.
+func (l FindingList) Error() string {
+	messages := make([]string, len(l))
+	for i := range l {
+		messages[i] = l[i].Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the findings as errors.
+func (l FindingList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i := range l {
+		errs[i] = &l[i]
+	}
+	return errs
+}
+
+// Fix is a correction of a Finding.
+// It consists of one or more changes that need to be applied in the given order.
+type Fix struct {
+	// Description is shown instead of the changes, if set.
+	Description string
+	Changes     []AttendanceChange
+}
+
+// Error implements error.
+func (f *Finding) Error() string {
+	return f.Err.Error()
+}
+
+func (f *Finding) Unwrap() error {
+	return f.Err
+}
+
+// Apply returns a copy of the given list with all changes applied.
+func (f Fix) Apply(list model.AttendanceList) (model.AttendanceList, error) {
+	var err error
+	for _, change := range f.Changes {
+		list, err = change.Apply(list)
+		if err != nil {
+			return list, err
+		}
+	}
+	return list, nil
+}
+
+// String returns a human-readable description of the fix.
+func (f Fix) String() string {
+	if f.Description != "" {
+		return f.Description
+	}
+	changes := make([]string, len(f.Changes))
+	for i, change := range f.Changes {
+		changes[i] = change.String()
+	}
+	return strings.Join(changes, ", ")
+}
+
+// isApplicable returns false if the fix changes attendances that don't exist in Odoo, e.g. the sign_out added by model.AttendanceList AddCurrentTimeAsSignOut.
+func (f Fix) isApplicable() bool {
+	for _, change := range f.Changes {
+		if change.Operation != OperationCreate && change.Attendance.ID == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Findings checks the shifts of the day for problems and returns all of them together with candidate corrections.
+// A shift may have an error and a warning, but there is at most one error per shift.
+// Corrections of attendances that don't exist in Odoo are included as well, see Fixer.
+func (s *DailySummary) Findings() []Finding {
+	day := s.Date.Format(odoo.DateFormat)
+	findings := make([]Finding, 0)
+	totalDuration := time.Duration(0)
+	for i := 0; i < len(s.Shifts); i++ {
+		if overlap, last, found := s.findOverlap(i); found {
+			findings = append(findings, overlap)
+			i = last
+			continue
+		}
+		shift := s.Shifts[i]
+		shiftDuration := shift.Duration()
+		totalDuration += shiftDuration
+		switch {
+		case shiftDuration == 0:
+			findings = append(findings, Finding{
+				Kind: KindZeroDuration, Severity: SeverityError, AttendanceIDs: []int{shift.Start.ID, shift.End.ID}, shift: i,
+				Err: fmt.Errorf("shift start and end times cannot be the same for %s: %s", day, shift.Start.DateTime.Format(odoo.TimeFormat)),
+				Fixes: []Fix{{Description: "Delete shift", Changes: []AttendanceChange{
+					{Operation: OperationDelete, Attendance: shift.End},
+					{Operation: OperationDelete, Attendance: shift.Start},
+				}}},
+			})
+		case !shift.Start.DateTime.IsZero() && shift.End.DateTime.IsZero():
+			findings = append(findings, s.findMissingSignOut(i))
+		case !shift.End.DateTime.IsZero() && shift.Start.DateTime.IsZero():
+			findings = append(findings, s.findMissingSignIn(i))
+		case shift.Start.Reason.String() != shift.End.Reason.String():
+			findings = append(findings, Finding{
+				Kind: KindReasonMismatch, Severity: SeverityError, AttendanceIDs: []int{shift.Start.ID, shift.End.ID}, shift: i,
+				Err: fmt.Errorf("the reasons for shift %s and %s should be equal: start %s (%s), end %s (%s)",
+					model.ActionSignIn, model.ActionSignOut, shift.Start.DateTime.Format(odoo.TimeFormat), shift.Start.Reason, shift.End.DateTime.Format(odoo.TimeFormat), shift.End.Reason),
+				Fixes: []Fix{
+					newReasonFix(shift.End, shift.Start.Reason),
+					newReasonFix(shift.Start, shift.End.Reason),
+				},
+			})
+		}
+		if !isInvalidShift(shift) && shiftDuration > longShiftDuration {
+			findings = append(findings, Finding{
+				Kind: KindLongShift, Severity: SeverityWarning, AttendanceIDs: attendanceIDs(shift.Start, shift.End), shift: i, Fixes: []Fix{},
+				Err: fmt.Errorf("shift from %s until %s on %s lasts %s, maybe the %s has been forgotten",
+					shift.Start.DateTime.Format(odoo.TimeFormat), shift.End.DateTime.Format(odoo.TimeFormat), day, shiftDuration, model.ActionSignOut),
+			})
+		}
+	}
+	if !hasErrors(findings) && totalDuration > 24*time.Hour {
+		// this shouldn't be possible in theory, but maybe someone forgot to sign out.
+		ids := make([]int, 0, 2*len(s.Shifts))
+		for _, shift := range s.Shifts {
+			ids = append(ids, attendanceIDs(shift.Start, shift.End)...)
+		}
+		findings = append(findings, Finding{
+			Kind: KindExceeds24h, Severity: SeverityError, AttendanceIDs: ids, shift: -1,
+			Err: fmt.Errorf("duration of all shifts for %s cannot exceed 24h: %s", day, totalDuration),
+		})
+	}
+	return findings
+}
+
+// hasErrors returns true if any of the given findings has SeverityError.
+func hasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// findOverlap returns the finding for shifts that overlap, starting with the i-th shift, and the index of the last overlapping shift.
+// Since the attendances are sorted by time, overlapping shifts are paired as a shift without end, followed by one or more complete shifts and a shift without start.
+// Duplicate attendances aren't considered overlaps.
+func (s *DailySummary) findOverlap(i int) (Finding, int, bool) {
+	outer := s.Shifts[i]
+	if outer.Start.DateTime.IsZero() || !outer.End.DateTime.IsZero() {
+		return Finding{}, i, false
+	}
+	for k := i + 1; k < len(s.Shifts); k++ {
+		shift := s.Shifts[k]
+		previous := s.Shifts[k-1]
+		switch {
+		case k == i+1 && shift.Start.DateTime.Equal(outer.Start.DateTime.Time):
+			return Finding{}, i, false // duplicate sign_in
+		case shift.Start.DateTime.IsZero() && !shift.End.DateTime.IsZero() && k > i+1:
+			if shift.End.DateTime.Equal(previous.End.DateTime.Time) {
+				return Finding{}, i, false // duplicate sign_out
+			}
+			return s.newOverlapFinding(i, k), k, true
+		case isInvalidShift(shift):
+			return Finding{}, i, false
+		}
+	}
+	return Finding{}, i, false
+}
+
+// overlappingShifts returns the indexes of the shifts that overlap with other shifts, see findOverlap.
+func (s *DailySummary) overlappingShifts() map[int]bool {
+	overlapping := map[int]bool{}
+	for i := 0; i < len(s.Shifts); i++ {
+		if _, last, found := s.findOverlap(i); found {
+			for k := i; k <= last; k++ {
+				overlapping[k] = true
+			}
+			i = last
+		}
+	}
+	return overlapping
+}
+
+// newOverlapFinding returns the finding for the i-th shift without end that is closed by the k-th shift without start.
+// The shifts in between are deleted to merge the shifts into one.
+func (s *DailySummary) newOverlapFinding(i, k int) Finding {
+	outer, closing := s.Shifts[i], s.Shifts[k]
+	ids := []int{outer.Start.ID}
+	merge := Fix{Description: "Merge overlapping shifts", Changes: []AttendanceChange{}}
+	for _, inner := range s.Shifts[i+1 : k] {
+		ids = append(ids, inner.Start.ID, inner.End.ID)
+		merge.Changes = append(merge.Changes,
+			AttendanceChange{Operation: OperationDelete, Attendance: inner.End},
+			AttendanceChange{Operation: OperationDelete, Attendance: inner.Start},
+		)
+	}
+	ids = append(ids, closing.End.ID)
+	return Finding{
+		Kind: KindOverlap, Severity: SeverityError, AttendanceIDs: ids, shift: i,
+		Err: fmt.Errorf("overlapping shifts detected for %s between %s and %s", s.Date.Format(odoo.DateFormat),
+			outer.Start.DateTime.Format(odoo.TimeFormat), closing.End.DateTime.Format(odoo.TimeFormat)),
+		Fixes: []Fix{merge},
+	}
+}
+
+// findMissingSignOut returns the finding for the shift that has no end.
+// A sign_in with the same timestamp as the next one is a duplicate, otherwise the shift is ended after the remaining daily working time.
+// The suggested sign_out has to be before the next sign_in, if there is one.
+func (s *DailySummary) findMissingSignOut(i int) Finding {
+	day := s.Date.Format(odoo.DateFormat)
+	start := s.Shifts[i].Start
+	if i+1 < len(s.Shifts) && s.Shifts[i+1].Start.DateTime.Equal(start.DateTime.Time) {
+		return Finding{
+			Kind: KindDuplicateAttendance, Severity: SeverityError, AttendanceIDs: []int{start.ID}, shift: i,
+			Err:   fmt.Errorf("duplicate %s detected for %s at %s", model.ActionSignIn, day, start.DateTime.Format(odoo.TimeFormat)),
+			Fixes: []Fix{{Changes: []AttendanceChange{{Operation: OperationDelete, Attendance: start}}}},
+		}
+	}
+	finding := Finding{
+		Kind: KindMissingSignOut, Severity: SeverityError, AttendanceIDs: []int{start.ID}, shift: i,
+		Err:   fmt.Errorf("no %s detected for %s after %s", model.ActionSignOut, day, start.DateTime.Format(odoo.TimeFormat)),
+		Fixes: []Fix{},
+	}
+	// the sign_out may coincide with the next sign_in, but not with the next midnight
+	limit := s.Date.AddDate(0, 0, 1).Add(-time.Second)
+	if i+1 < len(s.Shifts) && !s.Shifts[i+1].Start.DateTime.IsZero() {
+		next := s.Shifts[i+1].Start
+		limit = next.DateTime.Time
+		finding.Kind = KindConsecutiveSignIn
+		finding.AttendanceIDs = []int{start.ID, next.ID}
+		finding.Err = fmt.Errorf("no %s detected for %s between %s at %s and %s at %s",
+			model.ActionSignOut, day, model.ActionSignIn, start.DateTime.Format(odoo.TimeFormat), model.ActionSignIn, next.DateTime.Format(odoo.TimeFormat))
+	}
+	end := start.DateTime.Add(s.remainingWorkingTime())
+	if end.After(start.DateTime.Time) && !end.After(limit) {
+		finding.Fixes = append(finding.Fixes, Fix{Changes: []AttendanceChange{{Operation: OperationCreate, Attendance: model.Attendance{
+			DateTime: odoo.Date{Time: end}, Action: model.ActionSignOut, Reason: reasonByName(start.Reason),
+		}}}})
+	}
+	return finding
+}
+
+// findMissingSignIn returns the finding for the shift that has no start.
+// A sign_out with the same timestamp as the previous one is a duplicate, otherwise the shift is started before the remaining daily working time.
+func (s *DailySummary) findMissingSignIn(i int) Finding {
+	day := s.Date.Format(odoo.DateFormat)
+	end := s.Shifts[i].End
+	if i > 0 && s.Shifts[i-1].End.DateTime.Equal(end.DateTime.Time) {
+		return Finding{
+			Kind: KindDuplicateAttendance, Severity: SeverityError, AttendanceIDs: []int{end.ID}, shift: i,
+			Err:   fmt.Errorf("duplicate %s detected for %s at %s", model.ActionSignOut, day, end.DateTime.Format(odoo.TimeFormat)),
+			Fixes: []Fix{{Changes: []AttendanceChange{{Operation: OperationDelete, Attendance: end}}}},
+		}
+	}
+	finding := Finding{
+		Kind: KindMissingSignIn, Severity: SeverityError, AttendanceIDs: []int{end.ID}, shift: i,
+		Err:   fmt.Errorf("no %s detected for %s before %s", model.ActionSignIn, day, end.DateTime.Format(odoo.TimeFormat)),
+		Fixes: []Fix{},
+	}
+	start := end.DateTime.Add(-s.remainingWorkingTime())
+	if start.Before(end.DateTime.Time) && !start.Before(s.Date) {
+		finding.Fixes = append(finding.Fixes, Fix{Changes: []AttendanceChange{{Operation: OperationCreate, Attendance: model.Attendance{
+			DateTime: odoo.Date{Time: start}, Action: model.ActionSignIn, Reason: reasonByName(end.Reason),
+		}}}})
+	}
+	return finding
+}
+
+// remainingWorkingTime returns the time that is missing to reach the daily maximum with the valid shifts of the day.
+func (s *DailySummary) remainingWorkingTime() time.Duration {
+	os := s.CalculateOvertimeSummary()
+	return os.DailyMax - os.RegularWorkingTime - os.OutOfOfficeTime - os.ExcusedTime()
+}
+
+// attendanceIDs returns the IDs of the given attendances that exist in Odoo.
+func attendanceIDs(attendances ...model.Attendance) []int {
+	ids := make([]int, 0, len(attendances))
+	for _, attendance := range attendances {
+		if attendance.ID != 0 {
+			ids = append(ids, attendance.ID)
+		}
+	}
+	return ids
+}
+
+// newReasonFix returns a fix that changes the reason of the given attendance.
+func newReasonFix(attendance model.Attendance, reason *model.ActionReason) Fix {
+	description := fmt.Sprintf("Remove reason of %s", attendance.Action)
+	if reason.String() != "" {
+		description = fmt.Sprintf("Change reason of %s to %s", attendance.Action, reason)
+	}
+	attendance.Reason = reasonByName(reason)
+	return Fix{Description: description, Changes: []AttendanceChange{{Operation: OperationUpdate, Attendance: attendance}}}
+}
+
+// reasonByName returns a reason with the name of the given reason, or nil if it's empty.
+// Odoo has a separate reason record for each action, so the ID of the reason has to be resolved for the action it's written to.
+func reasonByName(reason *model.ActionReason) *model.ActionReason {
+	if reason.String() == "" {
+		return nil
+	}
+	return &model.ActionReason{Name: reason.Name}
+}
+
+// Fixer suggests corrections for invalid days of a report.
+// In contrast to DailySummary.Findings, it only suggests corrections that can be applied in Odoo.
+type Fixer struct {
+	dailies []*DailySummary
+}
+
+// NewFixer returns a new Fixer for the given report.
+func NewFixer(report Report) *Fixer {
+	return &Fixer{dailies: report.DailySummaries}
+}
+
+// Findings returns the findings of the given day.
+// Fixes that change attendances unknown to Odoo are omitted.
+func (f *Fixer) Findings(date time.Time) []Finding {
+	for _, daily := range f.dailies {
+		if !isSameDay(daily.Date, date) {
+			continue
+		}
+		findings := daily.Findings()
+		for j := range findings {
+			findings[j].Fixes = filterApplicableFixes(findings[j].Fixes)
+		}
+		return findings
+	}
+	return []Finding{}
+}
+
+func filterApplicableFixes(fixes []Fix) []Fix {
+	filtered := make([]Fix, 0, len(fixes))
+	for _, fix := range fixes {
+		if fix.isApplicable() {
+			filtered = append(filtered, fix)
+		}
+	}
+	return filtered
+}
+
+func isSameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
@@ -0,0 +1,227 @@
+package timesheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+func TestDailySummary_Findings(t *testing.T) {
+	signIn := func(id, hour, minute int, reason string) model.Attendance {
+		return model.Attendance{ID: id, DateTime: odoo.NewDate(2021, 02, 03, hour, minute, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{ID: id, Name: reason}}
+	}
+	signOut := func(id, hour, minute int, reason string) model.Attendance {
+		return model.Attendance{ID: id, DateTime: odoo.NewDate(2021, 02, 03, hour, minute, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{ID: id, Name: reason}}
+	}
+	tests := map[string]struct {
+		givenShifts      []AttendanceShift
+		expectedKinds    []FindingKind
+		expectedIDs      [][]int
+		expectedFixes    [][]string
+		expectedFirstFix []AttendanceChange
+		// expectedSeverities defaults to SeverityError for all findings.
+		expectedSeverities []Severity
+	}{
+		"GivenValidShifts_ThenReturnNothing": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, ""), End: signOut(2, 12, 0, "")},
+				{Start: signIn(3, 13, 0, ""), End: signOut(4, 17, 0, "")},
+			},
+			expectedKinds: []FindingKind{},
+		},
+		"GivenMissingSignOut_ThenSuggestSignOutAfterRemainingTime": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, ""), End: signOut(2, 12, 0, "")},
+				{Start: signIn(3, 13, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindMissingSignOut},
+			expectedIDs:   [][]int{{3}},
+			expectedFixes: [][]string{{"Add sign_out at 17:00"}},
+			expectedFirstFix: []AttendanceChange{{Operation: OperationCreate, Attendance: model.Attendance{
+				DateTime: odoo.NewDate(2021, 02, 03, 17, 0, 0, zurichTZ), Action: model.ActionSignOut,
+			}}},
+		},
+		"GivenMissingSignOut_WhenDailyMaxReached_ThenSuggestNothing": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, ""), End: signOut(2, 17, 0, "")},
+				{Start: signIn(3, 20, 0, ReasonOutsideOfficeHours)},
+			},
+			expectedKinds: []FindingKind{KindMissingSignOut},
+			expectedIDs:   [][]int{{3}},
+			expectedFixes: [][]string{{}},
+		},
+		"GivenMissingSignIn_ThenSuggestSignInBeforeRemainingTime": {
+			givenShifts: []AttendanceShift{
+				{End: signOut(2, 17, 0, ReasonSickLeave)},
+			},
+			expectedKinds: []FindingKind{KindMissingSignIn},
+			expectedIDs:   [][]int{{2}},
+			expectedFixes: [][]string{{"Add sign_in at 09:00 (Sick / Medical Consultation)"}},
+			expectedFirstFix: []AttendanceChange{{Operation: OperationCreate, Attendance: model.Attendance{
+				DateTime: odoo.NewDate(2021, 02, 03, 9, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{Name: ReasonSickLeave},
+			}}},
+		},
+		"GivenDuplicateSignIn_ThenSuggestDelete": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, "")},
+				{Start: signIn(2, 8, 0, ""), End: signOut(3, 17, 0, "")},
+			},
+			expectedKinds:    []FindingKind{KindDuplicateAttendance},
+			expectedIDs:      [][]int{{1}},
+			expectedFixes:    [][]string{{"Delete sign_in"}},
+			expectedFirstFix: []AttendanceChange{{Operation: OperationDelete, Attendance: signIn(1, 8, 0, "")}},
+		},
+		"GivenDuplicateSignOut_ThenSuggestDelete": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, ""), End: signOut(2, 17, 0, "")},
+				{End: signOut(3, 17, 0, "")},
+			},
+			expectedKinds:    []FindingKind{KindDuplicateAttendance},
+			expectedIDs:      [][]int{{3}},
+			expectedFixes:    [][]string{{"Delete sign_out"}},
+			expectedFirstFix: []AttendanceChange{{Operation: OperationDelete, Attendance: signOut(3, 17, 0, "")}},
+		},
+		"GivenDifferentReasons_ThenSuggestAligningEitherReason": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, ReasonSickLeave), End: signOut(2, 17, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindReasonMismatch},
+			expectedIDs:   [][]int{{1, 2}},
+			expectedFixes: [][]string{{"Change reason of sign_out to Sick / Medical Consultation", "Remove reason of sign_in"}},
+			expectedFirstFix: []AttendanceChange{{Operation: OperationUpdate, Attendance: model.Attendance{
+				ID: 2, DateTime: odoo.NewDate(2021, 02, 03, 17, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{Name: ReasonSickLeave},
+			}}},
+		},
+		"GivenZeroDuration_ThenSuggestDeletingShift": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, ""), End: signOut(2, 8, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindZeroDuration},
+			expectedIDs:   [][]int{{1, 2}},
+			expectedFixes: [][]string{{"Delete shift"}},
+			expectedFirstFix: []AttendanceChange{
+				{Operation: OperationDelete, Attendance: signOut(2, 8, 0, "")},
+				{Operation: OperationDelete, Attendance: signIn(1, 8, 0, "")},
+			},
+		},
+		"GivenConsecutiveSignIns_ThenSuggestSignOutBeforeNextSignIn": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, "")},
+				{Start: signIn(2, 10, 0, ""), End: signOut(3, 16, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindConsecutiveSignIn},
+			expectedIDs:   [][]int{{1, 2}},
+			expectedFixes: [][]string{{"Add sign_out at 10:00"}},
+		},
+		"GivenConsecutiveSignIns_WhenRemainingTimeEndsAfterNextSignIn_ThenSuggestNothing": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, "")},
+				{Start: signIn(2, 9, 0, ""), End: signOut(3, 12, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindConsecutiveSignIn},
+			expectedIDs:   [][]int{{1, 2}},
+			expectedFixes: [][]string{{}},
+		},
+		"GivenOverlappingShifts_ThenSuggestMerging": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, "")},
+				{Start: signIn(2, 9, 0, ""), End: signOut(3, 12, 0, "")},
+				{End: signOut(4, 13, 0, "")},
+				{Start: signIn(5, 14, 0, ""), End: signOut(6, 17, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindOverlap},
+			expectedIDs:   [][]int{{1, 2, 3, 4}},
+			expectedFixes: [][]string{{"Merge overlapping shifts"}},
+			expectedFirstFix: []AttendanceChange{
+				{Operation: OperationDelete, Attendance: signOut(3, 12, 0, "")},
+				{Operation: OperationDelete, Attendance: signIn(2, 9, 0, "")},
+			},
+		},
+		"GivenDuplicateShift_ThenReturnDuplicatesInsteadOfOverlap": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 8, 0, "")},
+				{Start: signIn(2, 8, 0, ""), End: signOut(3, 17, 0, "")},
+				{End: signOut(4, 17, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindDuplicateAttendance, KindDuplicateAttendance},
+			expectedIDs:   [][]int{{1}, {4}},
+			expectedFixes: [][]string{{"Delete sign_in"}, {"Delete sign_out"}},
+		},
+		"GivenLongShift_ThenWarn": {
+			givenShifts: []AttendanceShift{
+				{Start: signIn(1, 7, 0, ReasonSickLeave), End: signOut(2, 20, 0, "")},
+			},
+			expectedKinds:      []FindingKind{KindReasonMismatch, KindLongShift},
+			expectedIDs:        [][]int{{1, 2}, {1, 2}},
+			expectedFixes:      [][]string{{"Change reason of sign_out to Sick / Medical Consultation", "Remove reason of sign_in"}, {}},
+			expectedSeverities: []Severity{SeverityError, SeverityWarning},
+		},
+		"GivenMultipleProblems_ThenReturnAll": {
+			givenShifts: []AttendanceShift{
+				{End: signOut(1, 7, 0, "")},
+				{Start: signIn(2, 8, 0, ""), End: signOut(3, 8, 0, "")},
+				{Start: signIn(4, 9, 0, "")},
+			},
+			expectedKinds: []FindingKind{KindMissingSignIn, KindZeroDuration, KindMissingSignOut},
+			expectedIDs:   [][]int{{1}, {2, 3}, {4}},
+			expectedFixes: [][]string{{}, {"Delete shift"}, {"Add sign_out at 17:00"}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &DailySummary{Shifts: tc.givenShifts, Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ), FTERatio: 1}
+			result := s.Findings()
+			require.Len(t, result, len(tc.expectedKinds))
+			for i, finding := range result {
+				assert.Equal(t, tc.expectedKinds[i], finding.Kind, "kind")
+				assert.Equal(t, tc.expectedIDs[i], finding.AttendanceIDs, "attendance IDs")
+				expectedSeverity := SeverityError
+				if tc.expectedSeverities != nil {
+					expectedSeverity = tc.expectedSeverities[i]
+				}
+				assert.Equal(t, expectedSeverity, finding.Severity, "severity")
+				fixes := make([]string, len(finding.Fixes))
+				for j, fix := range finding.Fixes {
+					fixes[j] = fix.String()
+				}
+				assert.Equal(t, tc.expectedFixes[i], fixes, "fixes")
+			}
+			if tc.expectedFirstFix != nil {
+				assert.Equal(t, tc.expectedFirstFix, result[0].Fixes[0].Changes)
+			}
+		})
+	}
+}
+
+func TestFixer_Findings(t *testing.T) {
+	day := NewDailySummary(1, time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ))
+	day.Shifts = []AttendanceShift{
+		{Start: model.Attendance{ID: 1, DateTime: odoo.NewDate(2021, 02, 03, 22, 0, 0, zurichTZ), Action: model.ActionSignIn}},
+	}
+	fixer := NewFixer(Report{DailySummaries: []*DailySummary{day}})
+
+	t.Run("GivenMissingSignOut_WhenRemainingTimeEndsNextDay_ThenSuggestNoFix", func(t *testing.T) {
+		result := fixer.Findings(day.Date)
+		require.Len(t, result, 1)
+		assert.Equal(t, KindMissingSignOut, result[0].Kind)
+		assert.Empty(t, result[0].Fixes)
+	})
+	t.Run("GivenFakedSignOut_ThenOmitFixesOfIt", func(t *testing.T) {
+		faked := NewDailySummary(1, time.Date(2021, 02, 05, 0, 0, 0, 0, zurichTZ))
+		faked.Shifts = []AttendanceShift{{
+			Start: model.Attendance{ID: 5, DateTime: odoo.NewDate(2021, 02, 05, 8, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{Name: ReasonSickLeave}},
+			End:   model.Attendance{DateTime: odoo.NewDate(2021, 02, 05, 9, 0, 0, zurichTZ), Action: model.ActionSignOut},
+		}}
+		result := NewFixer(Report{DailySummaries: []*DailySummary{faked}}).Findings(faked.Date)
+		require.Len(t, result, 1)
+		require.Len(t, result[0].Fixes, 1)
+		assert.Equal(t, "Remove reason of sign_in", result[0].Fixes[0].String())
+	})
+	t.Run("GivenUnknownDate_ThenReturnEmpty", func(t *testing.T) {
+		assert.Empty(t, fixer.Findings(time.Date(2021, 03, 01, 0, 0, 0, 0, zurichTZ)))
+	})
+}
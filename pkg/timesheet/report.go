@@ -8,6 +8,8 @@ import (
 	"github.com/vshn/odootools/pkg/odoo/model"
 )
 
+// Names of attendance reasons and leave types in VSHN's Odoo.
+// How they're accounted is defined by the RuleSet.
 const (
 	ReasonSickLeave          = "Sick / Medical Consultation"
 	ReasonOutsideOfficeHours = "Outside office hours"
@@ -28,9 +30,18 @@ const (
 // DefaultTimeZone is the zone to which apply a last-resort default.
 var DefaultTimeZone *time.Location
 
+// maxShiftDuration is the longest time between a sign_in and a sign_out on different days that is still paired to a shift.
+const maxShiftDuration = 24 * time.Hour
+
 type AttendanceShift struct {
 	Start model.Attendance
 	End   model.Attendance
+	// ContinuedFromPreviousDay is true if the shift is the portion of a shift that started on the previous day.
+	// Its Start is at midnight and not an attendance in Odoo.
+	ContinuedFromPreviousDay bool
+	// ContinuesNextDay is true if the shift is the portion of a shift that ends on the next day.
+	// Its End is at midnight of the next day and not an attendance in Odoo.
+	ContinuesNextDay bool
 }
 
 // String implements fmt.Stringer.
@@ -50,8 +61,12 @@ func (s *AttendanceShift) Duration() time.Duration {
 }
 
 type AbsenceBlock struct {
+	// Date is the localized day of the absence at midnight.
 	Date   time.Time
 	Reason string
+	// Duration is the time of the day that is covered by the absence.
+	// A full day absence may exceed the daily max, e.g. if it lasts from midnight to midnight.
+	Duration time.Duration
 }
 
 type Summary struct {
@@ -61,6 +76,7 @@ type Summary struct {
 	TotalOutOfOfficeTime time.Duration
 	// TotalLeave is the amount of paid leave days.
 	// This value respects FTE ratio, e.g. in a 50% ratio a public holiday is still counted as '1d'.
+	// Partial leaves count as fraction of the daily max, e.g. a 4h leave on a 8h day counts as '0.5d'.
 	TotalLeave      float64
 	AverageWorkload float64
 }
@@ -75,6 +91,34 @@ type Report struct {
 	To time.Time
 }
 
+// WeeklySummary contains the days of a calendar week within a Report.
+type WeeklySummary struct {
+	// Year and Week are the ISO 8601 week of the days.
+	Year           int
+	Week           int
+	DailySummaries []*DailySummary
+	Summary        Summary
+}
+
+// SplitByWeek groups the daily summaries by ISO 8601 week, which starts on Monday.
+// The first and last week are incomplete if the time range doesn't start on a Monday or end on a Sunday.
+func (r Report) SplitByWeek() []WeeklySummary {
+	weeks := make([]WeeklySummary, 0)
+	for _, daily := range r.DailySummaries {
+		year, week := daily.Date.ISOWeek()
+		if len(weeks) == 0 || weeks[len(weeks)-1].Year != year || weeks[len(weeks)-1].Week != week {
+			weeks = append(weeks, WeeklySummary{Year: year, Week: week})
+		}
+		last := &weeks[len(weeks)-1]
+		last.DailySummaries = append(last.DailySummaries, daily)
+	}
+	builder := ReportBuilder{}
+	for i := range weeks {
+		weeks[i].Summary = builder.summarize(weeks[i].DailySummaries)
+	}
+	return weeks
+}
+
 type ReportBuilder struct {
 	attendances model.AttendanceList
 	leaves      odoo.List[model.Leave]
@@ -109,7 +153,6 @@ func (r *ReportBuilder) SkipClampingToNow(skip bool) *ReportBuilder {
 func (r *ReportBuilder) CalculateReport(from time.Time, to time.Time) (Report, error) {
 	r.from = from
 	r.to = to
-	filteredAttendances := r.attendances.FilterAttendanceBetweenDates(r.from, r.to.Add(-1*time.Second))
 	filteredLeaves := r.filterLeavesInTimeRange()
 	absences := r.reduceLeavesToBlocks(filteredLeaves)
 	dailySummaries, err := r.prepareDays()
@@ -121,24 +164,12 @@ func (r *ReportBuilder) CalculateReport(from time.Time, to time.Time) (Report, e
 		}, err
 	}
 
-	r.addAttendancesToDailyShifts(filteredAttendances, dailySummaries)
+	r.addAttendancesToDailyShifts(r.attendances, dailySummaries)
 	r.addAbsencesToDailies(absences, dailySummaries)
 
-	summary := Summary{}
-	for _, dailySummary := range dailySummaries {
-		overtimeSummary := dailySummary.CalculateOvertimeSummary()
-		summary.TotalOvertime += overtimeSummary.Overtime()
-		summary.TotalExcusedTime += overtimeSummary.ExcusedTime()
-		summary.TotalWorkedTime += overtimeSummary.WorkingTime()
-		summary.TotalOutOfOfficeTime += overtimeSummary.OutOfOfficeTime
-		if dailySummary.IsHoliday() {
-			summary.TotalLeave += 1
-		}
-	}
-	summary.AverageWorkload = r.calculateAverageWorkload(dailySummaries)
 	return Report{
 		DailySummaries: dailySummaries,
-		Summary:        summary,
+		Summary:        r.summarize(dailySummaries),
 		Employee:       r.employee,
 		From:           r.from,
 		To:             r.to,
@@ -149,6 +180,9 @@ func (r *ReportBuilder) getTimeZone() *time.Location {
 	return r.from.Location()
 }
 
+// addAttendancesToDailyShifts pairs the attendances to shifts and adds them to the days on which they happened.
+// Shifts that cross midnight are split into a portion for each day.
+// Attendances outside the days are still considered, so that shifts crossing the boundaries of the time range are paired.
 func (r *ReportBuilder) addAttendancesToDailyShifts(attendances model.AttendanceList, dailies []*DailySummary) {
 	tz := r.getTimeZone()
 	dailyMap := make(map[string]*DailySummary, len(dailies))
@@ -156,47 +190,102 @@ func (r *ReportBuilder) addAttendancesToDailyShifts(attendances model.Attendance
 		dailyMap[dailySummary.Date.Format(odoo.DateFormat)] = dailySummary
 	}
 
-	for _, attendance := range attendances.Items {
-		date := attendance.DateTime.In(tz)
-		daily, exists := dailyMap[date.Format(odoo.DateFormat)]
-		if !exists {
-			continue // irrelevant attendance
-		}
-		var shift AttendanceShift
-		shiftCount := len(daily.Shifts)
-		newShift := false
-		if shiftCount == 0 {
-			shift = AttendanceShift{}
-			newShift = true
-		} else {
-			shift = daily.Shifts[shiftCount-1]
-		}
-		startDate := shift.Start.DateTime.Time
-		endDate := shift.End.DateTime.Time
-		if !endDate.IsZero() && (date.Equal(endDate) || date.After(endDate)) {
-			// new shift
-			shift = AttendanceShift{}
-			newShift = true
-		}
-		if !startDate.IsZero() && (attendance.Action == model.ActionSignIn) {
-			// start of shift already defined, which means we have 2 consecutive sign_ins.
-			// This is semantically invalid.
-			shift = AttendanceShift{}
-			newShift = true
+	for _, shift := range pairAttendancesToShifts(attendances, tz) {
+		for _, portion := range splitShiftAtMidnight(shift) {
+			date := portion.Start.DateTime.Time
+			if date.IsZero() {
+				date = portion.End.DateTime.Time
+			}
+			daily, exists := dailyMap[date.Format(odoo.DateFormat)]
+			if !exists {
+				continue // irrelevant attendance
+			}
+			daily.Shifts = append(daily.Shifts, portion)
 		}
-		attendance.DateTime.Time = date // correct timezone
+	}
+}
+
+// pairAttendancesToShifts returns the shifts of the given sorted attendances in the given zone.
+// A sign_in is paired with the following sign_out, unless they are on different days and more than maxShiftDuration apart.
+// Attendances that can't be paired, e.g. 2 consecutive sign_ins, result in shifts with either a zero start or end.
+func pairAttendancesToShifts(attendances model.AttendanceList, tz *time.Location) []AttendanceShift {
+	shifts := make([]AttendanceShift, 0, len(attendances.Items)/2)
+	var open *AttendanceShift
+	for _, attendance := range attendances.Items {
+		attendance.DateTime.Time = attendance.DateTime.In(tz) // correct timezone
 		switch attendance.Action {
 		case model.ActionSignIn:
-			shift.Start = attendance
+			if open != nil {
+				// start of shift already defined, which means we have 2 consecutive sign_ins.
+				// This is semantically invalid.
+				shifts = append(shifts, *open)
+			}
+			open = &AttendanceShift{Start: attendance}
 		case model.ActionSignOut:
-			shift.End = attendance
+			if open != nil && canPair(open.Start, attendance) {
+				open.End = attendance
+				shifts = append(shifts, *open)
+				open = nil
+				continue
+			}
+			if open != nil {
+				shifts = append(shifts, *open)
+				open = nil
+			}
+			shifts = append(shifts, AttendanceShift{End: attendance})
+		}
+	}
+	if open != nil {
+		shifts = append(shifts, *open)
+	}
+	return shifts
+}
+
+// canPair returns true if the given sign_in and sign_out belong to the same shift.
+func canPair(signIn, signOut model.Attendance) bool {
+	start, end := signIn.DateTime.Time, signOut.DateTime.Time
+	return isSameDay(start, end) || end.Sub(start) <= maxShiftDuration
+}
+
+// splitShiftAtMidnight returns the portions of the given shift per day.
+// The portions keep the reasons of the shift, and the boundaries at midnight are attendances that don't exist in Odoo (ID 0).
+// Incomplete shifts are returned as-is.
+func splitShiftAtMidnight(shift AttendanceShift) []AttendanceShift {
+	if isInvalidShift(shift) {
+		return []AttendanceShift{shift}
+	}
+	portions := make([]AttendanceShift, 0, 1)
+	portion := shift
+	for {
+		midnight := odoo.Midnight(portion.Start.DateTime.Time).AddDate(0, 0, 1)
+		if !shift.End.DateTime.After(midnight) {
+			break
 		}
-		if newShift {
-			daily.Shifts = append(daily.Shifts, shift)
-		} else {
-			daily.Shifts[shiftCount-1] = shift
+		portion.End = model.Attendance{DateTime: odoo.Date{Time: midnight}, Action: model.ActionSignOut, Reason: shift.End.Reason}
+		portion.ContinuesNextDay = true
+		portions = append(portions, portion)
+		portion = AttendanceShift{
+			Start:                    model.Attendance{DateTime: odoo.Date{Time: midnight}, Action: model.ActionSignIn, Reason: shift.Start.Reason},
+			ContinuedFromPreviousDay: true,
 		}
 	}
+	portion.End = shift.End
+	return append(portions, portion)
+}
+
+// summarize returns the sum of the given days.
+func (r *ReportBuilder) summarize(dailies []*DailySummary) Summary {
+	summary := Summary{}
+	for _, dailySummary := range dailies {
+		overtimeSummary := dailySummary.CalculateOvertimeSummary()
+		summary.TotalOvertime += overtimeSummary.Overtime()
+		summary.TotalExcusedTime += overtimeSummary.ExcusedTime()
+		summary.TotalWorkedTime += overtimeSummary.WorkingTime()
+		summary.TotalOutOfOfficeTime += overtimeSummary.OutOfOfficeTime
+		summary.TotalLeave += dailySummary.CalculateLeaveDays()
+	}
+	summary.AverageWorkload = r.calculateAverageWorkload(dailies)
+	return summary
 }
 
 func (r *ReportBuilder) calculateAverageWorkload(dailies []*DailySummary) float64 {
@@ -217,8 +306,9 @@ func (r *ReportBuilder) reduceLeavesToBlocks(leaves []model.Leave) []AbsenceBloc
 		if leave.State == StateApproved {
 			from := leave.DateFrom
 			blocks = append(blocks, AbsenceBlock{
-				Reason: leave.Type.String(),
-				Date:   odoo.Midnight(from.Time),
+				Reason:   leave.Type.String(),
+				Date:     odoo.Midnight(from.Time),
+				Duration: leave.Duration(),
 			})
 		}
 	}
@@ -246,7 +336,8 @@ func (r *ReportBuilder) prepareDays() ([]*DailySummary, error) {
 		if err != nil {
 			return days, err
 		}
-		days = append(days, NewDailySummary(currentRatio, currentDay.In(tz)))
+		daily := NewDailySummary(currentRatio, currentDay.In(tz))
+		days = append(days, daily)
 	}
 
 	return days, nil
@@ -268,20 +359,44 @@ func (r *ReportBuilder) addAbsencesToDailies(absences []AbsenceBlock, summaries
 	}
 }
 
+// filterLeavesInTimeRange returns the days of the leaves that are within the report, see splitLeaveByDay.
+// The day of ReportBuilder.to isn't part of the report.
 func (r *ReportBuilder) filterLeavesInTimeRange() []model.Leave {
 	filteredLeaves := make([]model.Leave, 0)
+	tz := r.getTimeZone()
 	for _, leave := range r.leaves.Items {
-		splits := leave.SplitByDay()
-		for _, split := range splits {
-			tz := r.getTimeZone()
-			from := split.DateFrom
-			date := odoo.Midnight(from.In(tz))
-			if odoo.IsWithinTimeRange(date, r.from, r.to) {
-				split.DateFrom.Time = odoo.LocalizeTime(split.DateFrom.Time, tz)
-				split.DateTo.Time = odoo.LocalizeTime(split.DateTo.Time, tz)
+		for _, split := range splitLeaveByDay(leave, tz) {
+			date := odoo.Midnight(split.DateFrom.Time)
+			if !date.Before(r.from) && date.Before(r.to) {
 				filteredLeaves = append(filteredLeaves, split)
 			}
 		}
 	}
 	return filteredLeaves
 }
+
+// splitLeaveByDay splits the leave into the days of the given zone, see model.Leave.SplitByDay.
+// The first and last day of a leave that spans multiple days are clipped to the working hours of DefaultRuleSet,
+// e.g. a leave from Monday 13:00 until Tuesday 17:00 covers only the afternoon on Monday and not the 11h until midnight.
+// Days that don't have any time within the working hours are omitted.
+func splitLeaveByDay(leave model.Leave, tz *time.Location) []model.Leave {
+	// split in the local zone, so that the leaves fall on the local days.
+	leave.DateFrom.Time = leave.DateFrom.In(tz)
+	leave.DateTo.Time = leave.DateTo.In(tz)
+	days := leave.SplitByDay()
+	if len(days) <= 1 {
+		return days
+	}
+	result := make([]model.Leave, 0, len(days))
+	for i, day := range days {
+		if i == 0 || i == len(days)-1 {
+			clipped, found := DefaultRuleSet.RulesFor(day.DateFrom.Time).ClipToWorkingHours(day)
+			if !found {
+				continue
+			}
+			day = clipped
+		}
+		result = append(result, day)
+	}
+	return result
+}
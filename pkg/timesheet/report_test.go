@@ -169,6 +169,80 @@ func TestReporter_addAbsencesToDailies(t *testing.T) {
 				},
 			},
 		},
+		"GivenShiftAcrossMidnight_ThenSplitIntoContinuationShifts": {
+			givenTimeZone: zurichTZ,
+			givenAttendances: []model.Attendance{
+				{ID: 1, DateTime: odoo.NewDate(2021, 02, 03, 22, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{Name: "On-call"}},
+				{ID: 2, DateTime: odoo.NewDate(2021, 02, 04, 2, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{Name: "On-call"}},
+			},
+			givenDailySummaries: []*DailySummary{
+				{Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ)},
+				{Date: time.Date(2021, 02, 04, 0, 0, 0, 0, zurichTZ)},
+			},
+			expectedDailies: []*DailySummary{
+				{
+					Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ),
+					Shifts: []AttendanceShift{{
+						Start:            model.Attendance{ID: 1, DateTime: odoo.NewDate(2021, 02, 03, 22, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{Name: "On-call"}},
+						End:              model.Attendance{DateTime: odoo.NewDate(2021, 02, 04, 0, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{Name: "On-call"}},
+						ContinuesNextDay: true,
+					}},
+				},
+				{
+					Date: time.Date(2021, 02, 04, 0, 0, 0, 0, zurichTZ),
+					Shifts: []AttendanceShift{{
+						Start:                    model.Attendance{DateTime: odoo.NewDate(2021, 02, 04, 0, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{Name: "On-call"}},
+						End:                      model.Attendance{ID: 2, DateTime: odoo.NewDate(2021, 02, 04, 2, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{Name: "On-call"}},
+						ContinuedFromPreviousDay: true,
+					}},
+				},
+			},
+		},
+		"GivenShiftStartedBeforeFirstDay_ThenAddContinuationOnly": {
+			givenTimeZone: zurichTZ,
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 02, 02, 23, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{}},
+				{DateTime: odoo.NewDate(2021, 02, 03, 1, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{}},
+			},
+			givenDailySummaries: []*DailySummary{
+				{Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ)},
+			},
+			expectedDailies: []*DailySummary{
+				{
+					Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ),
+					Shifts: []AttendanceShift{{
+						Start:                    model.Attendance{DateTime: odoo.NewDate(2021, 02, 03, 0, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{}},
+						End:                      model.Attendance{DateTime: odoo.NewDate(2021, 02, 03, 1, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{}},
+						ContinuedFromPreviousDay: true,
+					}},
+				},
+			},
+		},
+		"GivenSignOutMoreThan24hAfterSignIn_ThenDontPair": {
+			givenTimeZone: zurichTZ,
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 02, 03, 8, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{}},
+				{DateTime: odoo.NewDate(2021, 02, 04, 9, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{}},
+			},
+			givenDailySummaries: []*DailySummary{
+				{Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ)},
+				{Date: time.Date(2021, 02, 04, 0, 0, 0, 0, zurichTZ)},
+			},
+			expectedDailies: []*DailySummary{
+				{
+					Date: time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ),
+					Shifts: []AttendanceShift{
+						{Start: model.Attendance{DateTime: odoo.NewDate(2021, 02, 03, 8, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{}}},
+					},
+				},
+				{
+					Date: time.Date(2021, 02, 04, 0, 0, 0, 0, zurichTZ),
+					Shifts: []AttendanceShift{
+						{End: model.Attendance{DateTime: odoo.NewDate(2021, 02, 04, 9, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{}}},
+					},
+				},
+			},
+		},
 		"GivenAttendancesInVancouver_ThenSplitCorrectly": {
 			givenTimeZone: vancouverTZ,
 			givenAttendances: []model.Attendance{
@@ -226,7 +300,7 @@ func TestReportBuilder_prepareWorkDays(t *testing.T) {
 			givenYear:  2021,
 			givenMonth: 5,
 			givenContracts: []model.Contract{
-				{Start: odoo.MustParseDate("2021-01-01"), WorkingSchedule: &model.WorkingSchedule{Name: "100%"}},
+				{Start: odoo.MustParseDate("2021-01-01"), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
 			},
 			expectedDays: generateMonth(2021, 5, 31, zurichTZ),
 		},
@@ -240,7 +314,7 @@ func TestReportBuilder_prepareWorkDays(t *testing.T) {
 			givenYear:  2021,
 			givenMonth: 3,
 			givenContracts: []model.Contract{
-				{Start: odoo.MustParseDate("2021-01-01"), WorkingSchedule: &model.WorkingSchedule{Name: "100%"}},
+				{Start: odoo.MustParseDate("2021-01-01"), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
 			},
 			expectedDays: generateMonth(2021, 3, 7, zurichTZ),
 			now:          time.Date(2021, time.March, 7, 10, 32, 16, 0, time.UTC),
@@ -284,7 +358,7 @@ func TestReportBuilder_filterLeavesInTimeRange(t *testing.T) {
 		givenLeaves    odoo.List[model.Leave]
 		expectedLeaves []model.Leave
 	}{
-		"LeaveWithinSameMonth": {
+		"LeaveWithinSameMonth_ThenClipFirstAndLastDayToWorkingHours": {
 			givenTimezone: zurichTZ,
 			givenLeaves: odoo.List[model.Leave]{Items: []model.Leave{
 				{
@@ -294,12 +368,12 @@ func TestReportBuilder_filterLeavesInTimeRange(t *testing.T) {
 			}},
 			expectedLeaves: []model.Leave{
 				{
-					DateFrom: odoo.NewDate(2021, 02, 01, 0, 0, 0, zurichTZ),
-					DateTo:   odoo.NewDate(2021, 02, 01, 23, 59, 59, zurichTZ),
+					DateFrom: odoo.NewDate(2021, 02, 01, 8, 0, 0, zurichTZ),
+					DateTo:   odoo.NewDate(2021, 02, 01, 17, 0, 0, zurichTZ),
 				},
 				{
-					DateFrom: odoo.NewDate(2021, 02, 02, 0, 0, 0, zurichTZ),
-					DateTo:   odoo.NewDate(2021, 02, 02, 23, 59, 59, zurichTZ),
+					DateFrom: odoo.NewDate(2021, 02, 02, 8, 0, 0, zurichTZ),
+					DateTo:   odoo.NewDate(2021, 02, 02, 17, 0, 0, zurichTZ),
 				},
 			},
 		},
@@ -317,8 +391,48 @@ func TestReportBuilder_filterLeavesInTimeRange(t *testing.T) {
 					DateTo:   odoo.NewDate(2021, 02, 01, 23, 59, 59, zurichTZ),
 				},
 				{
-					DateFrom: odoo.NewDate(2021, 02, 02, 0, 0, 0, zurichTZ),
-					DateTo:   odoo.NewDate(2021, 02, 02, 23, 59, 59, zurichTZ),
+					DateFrom: odoo.NewDate(2021, 02, 02, 8, 0, 0, zurichTZ),
+					DateTo:   odoo.NewDate(2021, 02, 02, 17, 0, 0, zurichTZ),
+				},
+			},
+		},
+		"LeaveOnFirstDayOfNextMonth_ThenExclude": {
+			// the end of the report is exclusive
+			givenTimezone: zurichTZ,
+			givenLeaves: odoo.List[model.Leave]{Items: []model.Leave{
+				{
+					DateFrom: odoo.NewDate(2021, 02, 28, 23, 0, 0, time.UTC),
+					DateTo:   odoo.NewDate(2021, 03, 01, 22, 59, 59, time.UTC),
+				},
+			}},
+		},
+		"LeaveOnLastDayOfMonthInUTC_WhenFirstDayOfMonthInZurich_ThenInclude": {
+			givenTimezone: zurichTZ,
+			givenLeaves: odoo.List[model.Leave]{Items: []model.Leave{
+				{
+					DateFrom: odoo.NewDate(2021, 01, 31, 23, 0, 0, time.UTC),
+					DateTo:   odoo.NewDate(2021, 02, 01, 3, 0, 0, time.UTC),
+				},
+			}},
+			expectedLeaves: []model.Leave{
+				{
+					DateFrom: odoo.NewDate(2021, 02, 01, 0, 0, 0, zurichTZ),
+					DateTo:   odoo.NewDate(2021, 02, 01, 4, 0, 0, zurichTZ),
+				},
+			},
+		},
+		"LeaveOnFirstDayOfNextMonthInUTC_WhenLastDayOfMonthInVancouver_ThenInclude": {
+			givenTimezone: vancouverTZ,
+			givenLeaves: odoo.List[model.Leave]{Items: []model.Leave{
+				{
+					DateFrom: odoo.NewDate(2021, 03, 01, 1, 0, 0, time.UTC),
+					DateTo:   odoo.NewDate(2021, 03, 01, 5, 0, 0, time.UTC),
+				},
+			}},
+			expectedLeaves: []model.Leave{
+				{
+					DateFrom: odoo.NewDate(2021, 02, 28, 17, 0, 0, vancouverTZ),
+					DateTo:   odoo.NewDate(2021, 02, 28, 21, 0, 0, vancouverTZ),
 				},
 			},
 		},
@@ -331,7 +445,12 @@ func TestReportBuilder_filterLeavesInTimeRange(t *testing.T) {
 			}
 			b.leaves = tc.givenLeaves
 			result := b.filterLeavesInTimeRange()
-			assert.Equal(t, tc.expectedLeaves, result)
+			require.Len(t, result, len(tc.expectedLeaves))
+			for i, expected := range tc.expectedLeaves {
+				assert.True(t, expected.DateFrom.Equal(result[i].DateFrom.Time), "from: expected %s, got %s", expected.DateFrom, result[i].DateFrom)
+				assert.True(t, expected.DateTo.Equal(result[i].DateTo.Time), "to: expected %s, got %s", expected.DateTo, result[i].DateTo)
+				assert.Equal(t, tc.givenTimezone, result[i].DateFrom.Location(), "should be localized")
+			}
 		})
 	}
 }
@@ -450,7 +569,7 @@ func TestReportBuilder_CalculateReport(t *testing.T) {
 	}}
 	givenEmployee := model.Employee{Name: "💃"}
 	givenContracts := model.ContractList{Items: []model.Contract{
-		{Start: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Name: "100%"}},
+		{Start: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
 	}}
 	b := NewReporter(givenAttendances, givenLeaves, givenEmployee, givenContracts)
 	now := time.Date(2021, 01, 07, 17, 5, 0, 0, zurichTZ)
@@ -470,3 +589,192 @@ func TestReportBuilder_CalculateReport(t *testing.T) {
 	assert.Equal(t, 1.0, report.Summary.AverageWorkload, "average workload")
 	assert.Equal(t, (2)*time.Hour, report.Summary.TotalOutOfOfficeTime, "total out of office time")
 }
+
+func TestReport_SplitByWeek(t *testing.T) {
+	contracts := model.ContractList{Items: []model.Contract{
+		{Start: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
+	}}
+	attendances := model.AttendanceList{Items: []model.Attendance{
+		// Thursday, 1h overtime
+		{DateTime: odoo.NewDate(2021, 12, 30, 8, 0, 0, zurichTZ), Action: model.ActionSignIn},
+		{DateTime: odoo.NewDate(2021, 12, 30, 17, 0, 0, zurichTZ), Action: model.ActionSignOut},
+		// Monday, 2h overtime
+		{DateTime: odoo.NewDate(2022, 01, 03, 8, 0, 0, zurichTZ), Action: model.ActionSignIn},
+		{DateTime: odoo.NewDate(2022, 01, 03, 18, 0, 0, zurichTZ), Action: model.ActionSignOut},
+	}}
+	// Thursday until Tuesday, across the year boundary
+	report, err := NewReporter(attendances, odoo.List[model.Leave]{}, model.Employee{}, contracts).
+		SkipClampingToNow(true).
+		CalculateReport(time.Date(2021, 12, 30, 0, 0, 0, 0, zurichTZ), time.Date(2022, 01, 05, 0, 0, 0, 0, zurichTZ))
+	require.NoError(t, err)
+
+	result := report.SplitByWeek()
+	require.Len(t, result, 2)
+	assert.Equal(t, 2021, result[0].Year)
+	assert.Equal(t, 52, result[0].Week)
+	assert.Len(t, result[0].DailySummaries, 4, "Thursday until Sunday")
+	assert.Equal(t, time.Hour-8*time.Hour, result[0].Summary.TotalOvertime, "Friday is undertime")
+	assert.Equal(t, 2022, result[1].Year)
+	assert.Equal(t, 1, result[1].Week)
+	assert.Len(t, result[1].DailySummaries, 2, "Monday and Tuesday")
+	assert.Equal(t, 2*time.Hour-8*time.Hour, result[1].Summary.TotalOvertime, "Tuesday is undertime")
+	assert.Equal(t, report.Summary.TotalOvertime, result[0].Summary.TotalOvertime+result[1].Summary.TotalOvertime)
+}
+
+func TestReportBuilder_CalculateReport_GivenShiftsAcrossMidnight(t *testing.T) {
+	contracts := model.ContractList{Items: []model.Contract{
+		{Start: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
+	}}
+	outsideOfficeHours := &model.ActionReason{Name: ReasonOutsideOfficeHours}
+	tests := map[string]struct {
+		givenFrom        time.Time
+		givenAttendances []model.Attendance
+		expectedWorked   []time.Duration
+	}{
+		"GivenShiftAcrossMonthBoundary_ThenCountPortionOfMonth": {
+			givenFrom: time.Date(2021, 02, 01, 0, 0, 0, 0, zurichTZ),
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 01, 31, 22, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: outsideOfficeHours},
+				{DateTime: odoo.NewDate(2021, 02, 01, 2, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: outsideOfficeHours},
+			},
+			expectedWorked: []time.Duration{3 * time.Hour, 0},
+		},
+		"GivenShiftAcrossEndOfRange_ThenCountPortionOfLastDay": {
+			givenFrom: time.Date(2021, 02, 01, 0, 0, 0, 0, zurichTZ),
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 02, 02, 23, 0, 0, zurichTZ), Action: model.ActionSignIn},
+				{DateTime: odoo.NewDate(2021, 02, 03, 1, 0, 0, zurichTZ), Action: model.ActionSignOut},
+			},
+			expectedWorked: []time.Duration{0, time.Hour},
+		},
+		"GivenShiftInDSTNight_ThenCountActualTime": {
+			// clocks are set forward from 02:00 to 03:00 on Sunday
+			givenFrom: time.Date(2021, 03, 27, 0, 0, 0, 0, zurichTZ),
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 03, 27, 22, 0, 0, zurichTZ), Action: model.ActionSignIn},
+				{DateTime: odoo.NewDate(2021, 03, 28, 4, 0, 0, zurichTZ), Action: model.ActionSignOut},
+			},
+			expectedWorked: []time.Duration{2 * time.Hour, 3 * time.Hour},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			report, err := NewReporter(model.AttendanceList{Items: tc.givenAttendances}, odoo.List[model.Leave]{}, model.Employee{}, contracts).
+				SkipClampingToNow(true).
+				CalculateReport(tc.givenFrom, tc.givenFrom.AddDate(0, 0, 2))
+			require.NoError(t, err)
+			require.Len(t, report.DailySummaries, len(tc.expectedWorked))
+			for i, daily := range report.DailySummaries {
+				assert.NoError(t, daily.ValidateTimesheetEntries())
+				assert.Equal(t, tc.expectedWorked[i], daily.CalculateOvertimeSummary().WorkingTime(), daily.Date.Format(odoo.DateFormat))
+			}
+		})
+	}
+}
+
+func TestReportBuilder_CalculateReport_GivenPartialLeaves(t *testing.T) {
+	contracts := model.ContractList{Items: []model.Contract{
+		{Start: odoo.NewDate(2020, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
+	}}
+	legalLeaves := &model.LeaveType{Name: TypeLegalLeavesPrefix}
+	tests := map[string]struct {
+		givenTimeZone       *time.Location
+		givenFrom           time.Time
+		givenAttendances    []model.Attendance
+		givenLeaves         []model.Leave
+		expectedTotalLeave  float64
+		expectedOvertime    time.Duration
+		expectedDailyLeaves map[string]time.Duration
+	}{
+		"GivenHalfDayLeave_WhenWorkingTheOtherHalf_ThenNoUndertime": {
+			givenTimeZone: zurichTZ,
+			givenFrom:     time.Date(2021, 02, 03, 0, 0, 0, 0, zurichTZ),
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 02, 03, 13, 0, 0, zurichTZ), Action: model.ActionSignIn},
+				{DateTime: odoo.NewDate(2021, 02, 03, 17, 0, 0, zurichTZ), Action: model.ActionSignOut},
+			},
+			givenLeaves: []model.Leave{
+				// 08:00 - 12:00 in Zurich
+				{DateFrom: odoo.MustParseDateTime("2021-02-03 07:00:00"), DateTo: odoo.MustParseDateTime("2021-02-03 11:00:00"), Type: legalLeaves, State: StateApproved},
+			},
+			expectedTotalLeave:  0.5,
+			expectedOvertime:    0,
+			expectedDailyLeaves: map[string]time.Duration{"2021-02-03": 4 * time.Hour},
+		},
+		"GivenHourlyLeave_InVancouver_ThenApplyToLocalDay": {
+			givenTimeZone: vancouverTZ,
+			givenFrom:     time.Date(2021, 02, 03, 0, 0, 0, 0, vancouverTZ),
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 02, 03, 8, 0, 0, vancouverTZ), Action: model.ActionSignIn},
+				{DateTime: odoo.NewDate(2021, 02, 03, 14, 0, 0, vancouverTZ), Action: model.ActionSignOut},
+			},
+			givenLeaves: []model.Leave{
+				// 2021-02-03 22:00 - 2021-02-04 00:00 UTC is 14:00 - 16:00 in Vancouver
+				{DateFrom: odoo.MustParseDateTime("2021-02-03 22:00:00"), DateTo: odoo.MustParseDateTime("2021-02-04 00:00:00"), Type: legalLeaves, State: StateApproved},
+			},
+			expectedTotalLeave:  0.25,
+			expectedOvertime:    0,
+			expectedDailyLeaves: map[string]time.Duration{"2021-02-03": 2 * time.Hour},
+		},
+		"GivenFullDayLeaves_WhenSpanningDaylightSavingTimeSwitch_ThenCountWorkingDays": {
+			givenTimeZone: zurichTZ,
+			givenFrom:     time.Date(2021, 03, 26, 0, 0, 0, 0, zurichTZ),
+			givenLeaves: []model.Leave{
+				// Friday to Monday in Zurich, the offset changes from +1 to +2 on Sunday.
+				{DateFrom: odoo.MustParseDateTime("2021-03-25 23:00:00"), DateTo: odoo.MustParseDateTime("2021-03-29 21:59:59"), Type: legalLeaves, State: StateApproved},
+			},
+			expectedTotalLeave: 2,
+			expectedOvertime:   0,
+			expectedDailyLeaves: map[string]time.Duration{
+				"2021-03-26": 9 * time.Hour,
+				"2021-03-27": 24*time.Hour - time.Second,
+				"2021-03-28": 23*time.Hour - time.Second,
+				"2021-03-29": 9 * time.Hour,
+			},
+		},
+		"GivenMultiDayLeave_WhenStartingAtNoon_ThenCountHalfDayOnFirstDay": {
+			givenTimeZone: zurichTZ,
+			givenFrom:     time.Date(2021, 02, 01, 0, 0, 0, 0, zurichTZ),
+			givenAttendances: []model.Attendance{
+				{DateTime: odoo.NewDate(2021, 02, 01, 8, 0, 0, zurichTZ), Action: model.ActionSignIn},
+				{DateTime: odoo.NewDate(2021, 02, 01, 12, 0, 0, zurichTZ), Action: model.ActionSignOut},
+			},
+			givenLeaves: []model.Leave{
+				// Monday 13:00 until Tuesday 17:00 in Zurich
+				{DateFrom: odoo.MustParseDateTime("2021-02-01 12:00:00"), DateTo: odoo.MustParseDateTime("2021-02-02 16:00:00"), Type: legalLeaves, State: StateApproved},
+			},
+			expectedTotalLeave:  1.5,
+			expectedOvertime:    0,
+			expectedDailyLeaves: map[string]time.Duration{"2021-02-01": 4 * time.Hour, "2021-02-02": 9 * time.Hour},
+		},
+		"GivenSingleDayLeave_WhenShorterThanDailyMax_ThenCountFractionOfDay": {
+			// Leaves within a day aren't clipped or rounded up: 8h of 8.5h before 2021 are 0.94 days.
+			givenTimeZone: zurichTZ,
+			givenFrom:     time.Date(2020, 02, 03, 0, 0, 0, 0, zurichTZ),
+			givenLeaves: []model.Leave{
+				// 08:00 - 16:00 in Zurich
+				{DateFrom: odoo.MustParseDateTime("2020-02-03 07:00:00"), DateTo: odoo.MustParseDateTime("2020-02-03 15:00:00"), Type: legalLeaves, State: StateApproved},
+			},
+			expectedTotalLeave:  8 / 8.5,
+			expectedOvertime:    -30 * time.Minute,
+			expectedDailyLeaves: map[string]time.Duration{"2020-02-03": 8 * time.Hour},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := NewReporter(model.AttendanceList{Items: tc.givenAttendances}, odoo.List[model.Leave]{Items: tc.givenLeaves}, model.Employee{}, contracts).
+				SkipClampingToNow(true)
+			to := tc.givenFrom.AddDate(0, 0, len(tc.expectedDailyLeaves))
+			report, err := b.CalculateReport(tc.givenFrom, to)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOvertime, report.Summary.TotalOvertime, "total overtime")
+			assert.InDelta(t, tc.expectedTotalLeave, report.Summary.TotalLeave, 0.0001, "total leave")
+			require.Len(t, report.DailySummaries, len(tc.expectedDailyLeaves))
+			for _, daily := range report.DailySummaries {
+				date := daily.Date.Format(odoo.DateFormat)
+				require.Len(t, daily.Absences, 1, date)
+				assert.Equal(t, tc.expectedDailyLeaves[date], daily.Absences[0].Duration, date)
+			}
+		})
+	}
+}
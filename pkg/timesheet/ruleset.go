@@ -0,0 +1,266 @@
+package timesheet
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ReasonCategory defines how the time of an attendance reason is accounted.
+type ReasonCategory string
+
+const (
+	// CategoryWork counts the time as working time, which can result in overtime.
+	CategoryWork ReasonCategory = "work"
+	// CategoryExcused fills up the daily max if the working time is less, but doesn't result in overtime.
+	CategoryExcused ReasonCategory = "excused"
+)
+
+//go:embed default-rules.yaml
+var defaultRules []byte
+
+// DefaultRuleSet is the rule set that applies if no other is given.
+// It contains the rules of VSHN, see default-rules.yaml.
+var DefaultRuleSet = mustParseRuleSet(defaultRules)
+
+// RuleSet contains the business rules of the overtime calculation that changed over time.
+type RuleSet struct {
+	// Rules are sorted by Rules.ValidFrom.
+	Rules []*Rules `yaml:"rules"`
+}
+
+// Rules are the business rules that apply from Rules.ValidFrom until the next Rules in the RuleSet.
+type Rules struct {
+	// ValidFrom is the first day the rules apply, formatted as "2006-01-02".
+	ValidFrom string `yaml:"validFrom"`
+	// WeeklyHours are the hours of a 100% workload per week.
+	// The daily max is distributed evenly over the WorkingDays.
+	WeeklyHours float64 `yaml:"weeklyHours"`
+	// WorkingDays are the English names of the weekdays, e.g. "Monday".
+	WorkingDays []string `yaml:"workingDays"`
+	// Reasons maps the attendance reasons to their category.
+	// Attendances without reason are mapped with an empty name.
+	// Attendances with reasons that aren't listed are ignored.
+	Reasons []ReasonRule `yaml:"reasons"`
+	// PaidLeaveTypes are the names of leave types which reduce the daily max.
+	// "*" matches all leave types, and a "!" prefix excludes a leave type.
+	// The last matching entry wins.
+	PaidLeaveTypes []string `yaml:"paidLeaveTypes"`
+	// WorkingHours is the time of the day in which the daily max is usually worked.
+	// The first and last day of leaves that span multiple days are clipped to the working hours, since the leave lasts until midnight on the first day and from midnight on the last day.
+	// Leaves aren't clipped if unset.
+	WorkingHours *WorkingHours `yaml:"workingHours"`
+
+	validFrom   time.Time
+	workingDays map[time.Weekday]bool
+}
+
+// WorkingHours is a time range of the day.
+type WorkingHours struct {
+	// Start and End are the local times formatted as "15:04", e.g. "08:00" and "17:00".
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+
+	start, end time.Time
+}
+
+// ReasonRule maps an attendance reason to a category.
+type ReasonRule struct {
+	// Name is the name of the attendance reason.
+	Name     string         `yaml:"name"`
+	Category ReasonCategory `yaml:"category"`
+	// Multiplier is applied to the time of the attendance, e.g. 1.5 to compensate work outside office hours.
+	// Defaults to 1 if unset.
+	Multiplier float64 `yaml:"multiplier"`
+}
+
+// LoadRuleSetFile reads and validates the rule set from the given YAML file.
+func LoadRuleSetFile(path string) (*RuleSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadRuleSet(f)
+}
+
+// LoadRuleSet reads and validates the rule set in YAML format.
+func LoadRuleSet(r io.Reader) (*RuleSet, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	rs := &RuleSet{}
+	if err := decoder.Decode(rs); err != nil {
+		return nil, fmt.Errorf("cannot parse rule set: %w", err)
+	}
+	if err := rs.initialize(); err != nil {
+		return nil, fmt.Errorf("invalid rule set: %w", err)
+	}
+	return rs, nil
+}
+
+func mustParseRuleSet(b []byte) *RuleSet {
+	rs, err := LoadRuleSet(strings.NewReader(string(b)))
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+func (rs *RuleSet) initialize() error {
+	if len(rs.Rules) == 0 {
+		return errors.New("no rules defined")
+	}
+	for i, rules := range rs.Rules {
+		if err := rules.initialize(); err != nil {
+			return fmt.Errorf("rules valid from %q: %w", rules.ValidFrom, err)
+		}
+		if i > 0 && !rules.validFrom.After(rs.Rules[i-1].validFrom) {
+			return fmt.Errorf("rules valid from %q: not sorted by validFrom", rules.ValidFrom)
+		}
+	}
+	return nil
+}
+
+func (r *Rules) initialize() error {
+	validFrom, err := time.Parse(odoo.DateFormat, r.ValidFrom)
+	if err != nil {
+		return fmt.Errorf("cannot parse validFrom: %w", err)
+	}
+	r.validFrom = validFrom
+	if r.WeeklyHours <= 0 {
+		return errors.New("weeklyHours must be greater than 0")
+	}
+	r.workingDays = map[time.Weekday]bool{}
+	for _, name := range r.WorkingDays {
+		weekday, found := parseWeekday(name)
+		if !found {
+			return fmt.Errorf("unknown working day: %q", name)
+		}
+		r.workingDays[weekday] = true
+	}
+	if len(r.workingDays) == 0 {
+		return errors.New("no working days defined")
+	}
+	if r.WorkingHours != nil {
+		if err := r.WorkingHours.initialize(); err != nil {
+			return fmt.Errorf("workingHours: %w", err)
+		}
+	}
+	for i, reason := range r.Reasons {
+		if reason.Category != CategoryWork && reason.Category != CategoryExcused {
+			return fmt.Errorf("reason %q: unknown category: %q", reason.Name, reason.Category)
+		}
+		if reason.Multiplier < 0 {
+			return fmt.Errorf("reason %q: multiplier cannot be negative", reason.Name)
+		}
+		if reason.Multiplier == 0 {
+			r.Reasons[i].Multiplier = 1
+		}
+	}
+	return nil
+}
+
+func (h *WorkingHours) initialize() error {
+	start, err := time.Parse("15:04", h.Start)
+	if err != nil {
+		return fmt.Errorf("cannot parse start: %w", err)
+	}
+	end, err := time.Parse("15:04", h.End)
+	if err != nil {
+		return fmt.Errorf("cannot parse end: %w", err)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("end %q has to be after start %q", h.End, h.Start)
+	}
+	h.start, h.end = start, end
+	return nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// RulesFor returns the rules that apply to the given date.
+// The first rules apply to dates before their ValidFrom as well.
+func (rs *RuleSet) RulesFor(date time.Time) *Rules {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	rules := rs.Rules[0]
+	for _, candidate := range rs.Rules[1:] {
+		if candidate.validFrom.After(day) {
+			break
+		}
+		rules = candidate
+	}
+	return rules
+}
+
+// IsWorkingDay returns true if the given weekday is one of Rules.WorkingDays.
+func (r *Rules) IsWorkingDay(weekday time.Weekday) bool {
+	return r.workingDays[weekday]
+}
+
+// DailyMax returns the theoretical amount of hours that an employee with the given FTE ratio should work on the given weekday.
+// It returns 0 if it's not a working day.
+func (r *Rules) DailyMax(weekday time.Weekday, fteRatio float64) time.Duration {
+	if !r.IsWorkingDay(weekday) {
+		return 0
+	}
+	hours := r.WeeklyHours / float64(len(r.workingDays))
+	return time.Duration(hours * fteRatio * float64(time.Hour))
+}
+
+// ClipToWorkingHours returns the part of the leave that is within the WorkingHours on the day the leave starts.
+// It returns false if nothing of the leave is within the working hours.
+// The leave is returned unchanged if no working hours are defined.
+func (r *Rules) ClipToWorkingHours(leave model.Leave) (model.Leave, bool) {
+	if r.WorkingHours == nil {
+		return leave, true
+	}
+	from := leave.DateFrom.Time
+	start := time.Date(from.Year(), from.Month(), from.Day(), r.WorkingHours.start.Hour(), r.WorkingHours.start.Minute(), 0, 0, from.Location())
+	end := time.Date(from.Year(), from.Month(), from.Day(), r.WorkingHours.end.Hour(), r.WorkingHours.end.Minute(), 0, 0, from.Location())
+	if from.Before(start) {
+		leave.DateFrom = odoo.Date{Time: start}
+	}
+	if leave.DateTo.After(end) {
+		leave.DateTo = odoo.Date{Time: end}
+	}
+	return leave, leave.DateTo.After(leave.DateFrom.Time)
+}
+
+// Reason returns the rule of the given attendance reason name.
+// It returns false if the reason isn't mapped.
+func (r *Rules) Reason(name string) (ReasonRule, bool) {
+	for _, reason := range r.Reasons {
+		if reason.Name == name {
+			return reason, true
+		}
+	}
+	return ReasonRule{}, false
+}
+
+// IsPaidLeave returns true if an absence of the given leave type reduces the daily max.
+func (r *Rules) IsPaidLeave(leaveType string) bool {
+	paid := false
+	for _, pattern := range r.PaidLeaveTypes {
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		if pattern == "*" || pattern == leaveType {
+			paid = !exclude
+		}
+	}
+	return paid
+}
@@ -0,0 +1,221 @@
+package timesheet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+func TestRuleSet_RulesFor(t *testing.T) {
+	tests := map[string]struct {
+		givenDate        time.Time
+		expectedDailyMax time.Duration
+	}{
+		"GivenDateBeforeFirstRules_ThenUseFirstRules": {
+			givenDate:        time.Date(2010, time.February, 3, 0, 0, 0, 0, zurichTZ),
+			expectedDailyMax: 8*time.Hour + 30*time.Minute,
+		},
+		"GivenLastDayOf2020_ThenReturn8.5Hours": {
+			givenDate:        time.Date(2020, time.December, 31, 23, 0, 0, 0, zurichTZ),
+			expectedDailyMax: 8*time.Hour + 30*time.Minute,
+		},
+		"GivenFirstDayOf2021InZurich_ThenReturn8Hours": {
+			// 2020-12-31 23:00 in UTC
+			givenDate:        time.Date(2021, time.January, 1, 0, 0, 0, 0, zurichTZ),
+			expectedDailyMax: 8 * time.Hour,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := DefaultRuleSet.RulesFor(tc.givenDate)
+			assert.Equal(t, tc.expectedDailyMax, result.DailyMax(time.Thursday, 1))
+		})
+	}
+}
+
+func TestRules_IsPaidLeave(t *testing.T) {
+	rules := DefaultRuleSet.RulesFor(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, rules.IsPaidLeave("Legal Leaves 2022"))
+	assert.True(t, rules.IsPaidLeave(TypePublicHoliday))
+	assert.False(t, rules.IsPaidLeave(TypeUnpaid))
+
+	rules = &Rules{PaidLeaveTypes: []string{TypeMilitaryService}}
+	assert.True(t, rules.IsPaidLeave(TypeMilitaryService))
+	assert.False(t, rules.IsPaidLeave("Legal Leaves 2022"), "leave types aren't paid unless listed")
+}
+
+func TestRules_ClipToWorkingHours(t *testing.T) {
+	rules := DefaultRuleSet.RulesFor(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC))
+	tests := map[string]struct {
+		givenLeave    model.Leave
+		expectedLeave model.Leave
+		expectedFound bool
+	}{
+		"GivenAfternoon_ThenClipEnd": {
+			givenLeave:    model.Leave{DateFrom: odoo.NewDate(2022, 03, 07, 13, 0, 0, zurichTZ), DateTo: odoo.NewDate(2022, 03, 07, 23, 59, 59, zurichTZ)},
+			expectedLeave: model.Leave{DateFrom: odoo.NewDate(2022, 03, 07, 13, 0, 0, zurichTZ), DateTo: odoo.NewDate(2022, 03, 07, 17, 0, 0, zurichTZ)},
+			expectedFound: true,
+		},
+		"GivenFromMidnight_ThenClipStart": {
+			givenLeave:    model.Leave{DateFrom: odoo.NewDate(2022, 03, 07, 0, 0, 0, zurichTZ), DateTo: odoo.NewDate(2022, 03, 07, 12, 0, 0, zurichTZ)},
+			expectedLeave: model.Leave{DateFrom: odoo.NewDate(2022, 03, 07, 8, 0, 0, zurichTZ), DateTo: odoo.NewDate(2022, 03, 07, 12, 0, 0, zurichTZ)},
+			expectedFound: true,
+		},
+		"GivenEvening_ThenReturnFalse": {
+			givenLeave:    model.Leave{DateFrom: odoo.NewDate(2022, 03, 07, 18, 0, 0, zurichTZ), DateTo: odoo.NewDate(2022, 03, 07, 23, 59, 59, zurichTZ)},
+			expectedFound: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, found := rules.ClipToWorkingHours(tc.givenLeave)
+			assert.Equal(t, tc.expectedFound, found)
+			if tc.expectedFound {
+				assert.Equal(t, tc.expectedLeave, result)
+			}
+		})
+	}
+}
+
+func TestLoadRuleSet(t *testing.T) {
+	tests := map[string]struct {
+		givenYAML     string
+		expectedError string
+	}{
+		"GivenValidRules_ThenReturnRuleSet": {
+			givenYAML: `
+rules:
+  - validFrom: "2022-01-01"
+    weeklyHours: 32
+    workingDays: [Monday, Tuesday, Wednesday, Thursday]
+    reasons:
+      - {name: "", category: work}
+`,
+		},
+		"GivenNoRules_ThenReturnError": {
+			givenYAML:     `rules: []`,
+			expectedError: "invalid rule set: no rules defined",
+		},
+		"GivenUnknownField_ThenReturnError": {
+			givenYAML: `
+rules:
+  - validFrom: "2022-01-01"
+    dailyHours: 8
+`,
+			expectedError: "field dailyHours not found",
+		},
+		"GivenInvalidWeekday_ThenReturnError": {
+			givenYAML: `
+rules:
+  - validFrom: "2022-01-01"
+    weeklyHours: 40
+    workingDays: [Mon]
+`,
+			expectedError: `invalid rule set: rules valid from "2022-01-01": unknown working day: "Mon"`,
+		},
+		"GivenUnknownCategory_ThenReturnError": {
+			givenYAML: `
+rules:
+  - validFrom: "2022-01-01"
+    weeklyHours: 40
+    workingDays: [Monday]
+    reasons:
+      - {name: Sick, category: sick}
+`,
+			expectedError: `invalid rule set: rules valid from "2022-01-01": reason "Sick": unknown category: "sick"`,
+		},
+		"GivenReversedWorkingHours_ThenReturnError": {
+			givenYAML: `
+rules:
+  - validFrom: "2022-01-01"
+    weeklyHours: 40
+    workingDays: [Monday]
+    workingHours: {start: "17:00", end: "08:00"}
+`,
+			expectedError: `invalid rule set: rules valid from "2022-01-01": workingHours: end "08:00" has to be after start "17:00"`,
+		},
+		"GivenUnsortedRules_ThenReturnError": {
+			givenYAML: `
+rules:
+  - {validFrom: "2022-01-01", weeklyHours: 40, workingDays: [Monday]}
+  - {validFrom: "2021-01-01", weeklyHours: 40, workingDays: [Monday]}
+`,
+			expectedError: `invalid rule set: rules valid from "2021-01-01": not sorted by validFrom`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := LoadRuleSet(strings.NewReader(tc.givenYAML))
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Rules, 1)
+			reason, found := result.Rules[0].Reason("")
+			require.True(t, found)
+			assert.Equal(t, 1.0, reason.Multiplier, "multiplier should default to 1")
+		})
+	}
+}
+
+func TestReportBuilder_CalculateReport_GivenCustomRuleSet_ThenApplyRulesPerDate(t *testing.T) {
+	ruleSet, err := LoadRuleSet(strings.NewReader(`
+rules:
+  - validFrom: "2021-01-01"
+    weeklyHours: 40
+    workingDays: [Monday, Tuesday, Wednesday, Thursday, Friday]
+    reasons:
+      - {name: "", category: work}
+  - validFrom: "2021-02-03"
+    weeklyHours: 32
+    workingDays: [Monday, Tuesday, Wednesday, Thursday]
+    reasons:
+      - {name: "", category: work}
+      - {name: "On-call", category: work, multiplier: 2}
+      - {name: "Doctor", category: excused}
+    paidLeaveTypes: ["Legal Leaves 2021"]
+`))
+	require.NoError(t, err)
+	attendances := model.AttendanceList{Items: []model.Attendance{
+		// Tuesday: 9h with 8h daily max
+		{DateTime: odoo.NewDate(2021, 2, 2, 8, 0, 0, zurichTZ), Action: model.ActionSignIn},
+		{DateTime: odoo.NewDate(2021, 2, 2, 17, 0, 0, zurichTZ), Action: model.ActionSignOut},
+		// Wednesday: 7h + 1h on-call (counts 2h) with 8h daily max
+		{DateTime: odoo.NewDate(2021, 2, 3, 8, 0, 0, zurichTZ), Action: model.ActionSignIn},
+		{DateTime: odoo.NewDate(2021, 2, 3, 15, 0, 0, zurichTZ), Action: model.ActionSignOut},
+		{DateTime: odoo.NewDate(2021, 2, 3, 20, 0, 0, zurichTZ), Action: model.ActionSignIn, Reason: &model.ActionReason{Name: "On-call"}},
+		{DateTime: odoo.NewDate(2021, 2, 3, 21, 0, 0, zurichTZ), Action: model.ActionSignOut, Reason: &model.ActionReason{Name: "On-call"}},
+		// Friday: not a working day anymore, 2h overtime
+		{DateTime: odoo.NewDate(2021, 2, 5, 8, 0, 0, zurichTZ), Action: model.ActionSignIn},
+		{DateTime: odoo.NewDate(2021, 2, 5, 10, 0, 0, zurichTZ), Action: model.ActionSignOut},
+	}}
+	leaves := odoo.List[model.Leave]{Items: []model.Leave{
+		// Thursday: informational only, since not listed as paid
+		{DateFrom: odoo.NewDate(2021, 2, 4, 0, 0, 0, zurichTZ), DateTo: odoo.NewDate(2021, 2, 4, 23, 59, 59, zurichTZ), Type: &model.LeaveType{Name: TypeUnpaid}, State: StateApproved},
+	}}
+	contracts := model.ContractList{Items: []model.Contract{
+		{Start: odoo.NewDate(2021, 1, 1, 0, 0, 0, zurichTZ), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{ID: 1, Name: "Standard 100% Work Week"}}},
+	}}
+	defaultRuleSet := DefaultRuleSet
+	DefaultRuleSet = ruleSet
+	t.Cleanup(func() { DefaultRuleSet = defaultRuleSet })
+	report, err := NewReporter(attendances, leaves, model.Employee{}, contracts).
+		CalculateReport(time.Date(2021, 2, 2, 0, 0, 0, 0, zurichTZ), time.Date(2021, 2, 6, 0, 0, 0, 0, zurichTZ))
+	require.NoError(t, err)
+
+	require.Len(t, report.DailySummaries, 4)
+	expectedOvertime := []time.Duration{time.Hour, time.Hour, -8 * time.Hour, 2 * time.Hour}
+	for i, daily := range report.DailySummaries {
+		assert.Equal(t, expectedOvertime[i], daily.CalculateOvertimeSummary().Overtime(), daily.Date.Format(odoo.DateFormat))
+	}
+	assert.Equal(t, -4*time.Hour, report.Summary.TotalOvertime)
+	assert.Equal(t, time.Hour, report.Summary.TotalOutOfOfficeTime)
+	assert.Equal(t, 0.0, report.Summary.TotalLeave)
+}
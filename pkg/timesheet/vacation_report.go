@@ -0,0 +1,169 @@
+package timesheet
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+// VacationReport contains the holiday budget of an employee for a year.
+type VacationReport struct {
+	Employee model.Employee
+	Year     int
+	// FTERatio is the average workload of the year, which is used to prorate the allocations.
+	// Days without contract count as 0%.
+	FTERatio float64
+	Balances []VacationBalance
+	Summary  VacationBalance
+}
+
+// VacationBalance contains the days of a leave type.
+type VacationBalance struct {
+	// LeaveType is the name of the leave type, e.g. "Legal Leaves 2022".
+	LeaveType string
+	// Allocated is the sum of the approved allocations, prorated by VacationReport.FTERatio.
+	Allocated float64
+	// Taken is the number of weekdays of approved leaves.
+	Taken float64
+	// Pending is the number of weekdays of leaves that haven't been approved yet.
+	Pending float64
+	// Remaining is Allocated minus Taken.
+	Remaining float64
+}
+
+type VacationReportBuilder struct {
+	year        int
+	allocations odoo.List[model.Allocation]
+	leaves      odoo.List[model.Leave]
+	employee    model.Employee
+	contracts   model.ContractList
+	tz          *time.Location
+}
+
+// NewVacationReporter returns a new builder.
+// The leaves are expected to be of the types returned by LeaveTypesOfYear, regardless of their dates.
+func NewVacationReporter(allocations odoo.List[model.Allocation], leaves odoo.List[model.Leave], employee model.Employee, contracts model.ContractList) *VacationReportBuilder {
+	return &VacationReportBuilder{
+		allocations: allocations,
+		leaves:      leaves,
+		employee:    employee,
+		contracts:   contracts,
+		tz:          DefaultTimeZone,
+	}
+}
+
+func (r *VacationReportBuilder) SetYear(year int) *VacationReportBuilder {
+	r.year = year
+	return r
+}
+
+// SetTimeZone sets the zone in which the days of the leaves are counted.
+// DefaultTimeZone is used if not set.
+func (r *VacationReportBuilder) SetTimeZone(tz *time.Location) *VacationReportBuilder {
+	r.tz = tz
+	return r
+}
+
+// LeaveTypesOfYear returns the leave types that have been allocated for the given year.
+// The year of an allocation is given by the name of its leave type, e.g. "Legal Leaves 2022", since Odoo doesn't store the validity of allocations.
+// Leaves of these types belong to the balance of the year, even if they're taken in another year.
+func LeaveTypesOfYear(allocations odoo.List[model.Allocation], year int) []*model.LeaveType {
+	types := make([]*model.LeaveType, 0)
+	seen := map[int]bool{}
+	for _, allocation := range allocations.Items {
+		if allocation.Type.IsEmpty() || seen[allocation.Type.ID] || !strings.Contains(allocation.Type.Name, strconv.Itoa(year)) {
+			continue
+		}
+		seen[allocation.Type.ID] = true
+		types = append(types, allocation.Type)
+	}
+	return types
+}
+
+// CalculateVacationReport creates the report for the year.
+func (r *VacationReportBuilder) CalculateVacationReport() VacationReport {
+	report := VacationReport{
+		Employee: r.employee,
+		Year:     r.year,
+		FTERatio: r.calculateAverageFTERatio(),
+		Balances: []VacationBalance{},
+	}
+	balances := map[string]*VacationBalance{}
+	for _, leaveType := range LeaveTypesOfYear(r.allocations, r.year) {
+		balance := &VacationBalance{LeaveType: leaveType.Name}
+		balances[leaveType.Name] = balance
+		for _, allocation := range r.allocations.Items {
+			if allocation.State == StateApproved && allocation.Type.String() == leaveType.Name {
+				balance.Allocated += allocation.Days * report.FTERatio
+			}
+		}
+	}
+	for _, leave := range r.leaves.Items {
+		balance, found := balances[leave.Type.String()]
+		if !found {
+			continue
+		}
+		switch leave.State {
+		case StateApproved:
+			balance.Taken += r.countWeekdays(leave)
+		case StateToApprove:
+			balance.Pending += r.countWeekdays(leave)
+		}
+	}
+	for _, balance := range balances {
+		balance.Remaining = balance.Allocated - balance.Taken
+		report.Balances = append(report.Balances, *balance)
+		report.Summary.Allocated += balance.Allocated
+		report.Summary.Taken += balance.Taken
+		report.Summary.Pending += balance.Pending
+		report.Summary.Remaining += balance.Remaining
+	}
+	sort.Slice(report.Balances, func(i, j int) bool {
+		return report.Balances[i].LeaveType < report.Balances[j].LeaveType
+	})
+	return report
+}
+
+// calculateAverageFTERatio returns the average workload over all days of the year.
+func (r *VacationReportBuilder) calculateAverageFTERatio() float64 {
+	first := time.Date(r.year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	next := first.AddDate(1, 0, 0)
+	sum, days := 0.0, 0
+	for day := first; day.Before(next); day = day.AddDate(0, 0, 1) {
+		days++
+		ratio, err := r.contracts.GetFTERatioForDay(day)
+		if err != nil {
+			// not employed
+			continue
+		}
+		sum += ratio
+	}
+	return sum / float64(days)
+}
+
+// countWeekdays returns the number of days in the leave that are working days according to DefaultRuleSet.
+// Partial days count as fraction of the daily max, e.g. a 4h leave on a 8h day counts as 0.5 days, see splitLeaveByDay.
+func (r *VacationReportBuilder) countWeekdays(leave model.Leave) float64 {
+	tz := r.tz
+	if tz == nil {
+		tz = time.UTC
+	}
+	count := 0.0
+	for _, day := range splitLeaveByDay(leave, tz) {
+		ratio, err := r.contracts.GetFTERatioForDay(odoo.Midnight(day.DateFrom.Time))
+		if err != nil {
+			// not employed, count the leave as if full-time
+			ratio = 1
+		}
+		dailyMax := DefaultRuleSet.RulesFor(day.DateFrom.Time).DailyMax(day.DateFrom.Weekday(), ratio)
+		if dailyMax > 0 {
+			count += math.Min(float64(day.Duration())/float64(dailyMax), 1)
+		}
+	}
+	return count
+}
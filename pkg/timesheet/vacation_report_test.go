@@ -0,0 +1,100 @@
+package timesheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+)
+
+func TestVacationReportBuilder_CalculateVacationReport(t *testing.T) {
+	legal2021 := &model.LeaveType{ID: 17, Name: "Legal Leaves 2021"}
+	legal2022 := &model.LeaveType{ID: 18, Name: "Legal Leaves 2022"}
+	newLeave := func(leaveType *model.LeaveType, state, from, to string) model.Leave {
+		return model.Leave{Type: leaveType, State: state, DateFrom: odoo.MustParseDateTime(from), DateTo: odoo.MustParseDateTime(to)}
+	}
+	fullTime := model.Contract{Start: odoo.NewDate(2020, 1, 1, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{ID: 1, Name: "Standard 100% Work Week"}}}
+	tests := map[string]struct {
+		givenAllocations []model.Allocation
+		givenLeaves      []model.Leave
+		givenContracts   []model.Contract
+		expectedFTE      float64
+		expectedBalances []VacationBalance
+	}{
+		"GivenAllocationOfYear_ThenCountTakenAndPendingWeekdays": {
+			givenAllocations: []model.Allocation{
+				{Type: legal2021, Days: 25, State: StateApproved},
+				{Type: legal2022, Days: 25, State: StateApproved},
+				{Type: legal2022, Days: 2, State: StateToApprove},
+			},
+			givenLeaves: []model.Leave{
+				// Friday to Monday, localized: 2 weekdays
+				newLeave(legal2022, StateApproved, "2022-03-03 23:00:00", "2022-03-07 22:59:59"),
+				newLeave(legal2022, StateToApprove, "2022-04-10 22:00:00", "2022-04-12 21:59:59"),
+				newLeave(legal2022, "refuse", "2022-05-01 22:00:00", "2022-05-02 21:59:59"),
+				newLeave(legal2021, StateApproved, "2022-01-03 23:00:00", "2022-01-04 22:59:59"),
+				// half day
+				newLeave(legal2022, StateApproved, "2022-06-01 07:00:00", "2022-06-01 11:00:00"),
+				// Monday 13:00 until Tuesday 17:00, localized: 1.5 days
+				newLeave(legal2022, StateApproved, "2022-08-01 11:00:00", "2022-08-02 15:00:00"),
+			},
+			givenContracts:   []model.Contract{fullTime},
+			expectedFTE:      1,
+			expectedBalances: []VacationBalance{{LeaveType: "Legal Leaves 2022", Allocated: 25, Taken: 4, Pending: 2, Remaining: 21}},
+		},
+		"GivenPartTime_ThenProrateAllocation": {
+			givenAllocations: []model.Allocation{{Type: legal2022, Days: 25, State: StateApproved}},
+			givenContracts: []model.Contract{
+				{Start: odoo.NewDate(2020, 1, 1, 0, 0, 0, time.UTC), End: odoo.NewDate(2022, 6, 30, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{ID: 1, Name: "Standard 100% Work Week"}}},
+				{Start: odoo.NewDate(2022, 7, 1, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{ID: 1, Name: "Standard 50% Work Week"}}},
+			},
+			expectedFTE:      (181 + 0.5*184) / 365.0,
+			expectedBalances: []VacationBalance{{LeaveType: "Legal Leaves 2022", Allocated: 25 * (181 + 0.5*184) / 365.0, Remaining: 25 * (181 + 0.5*184) / 365.0}},
+		},
+		"GivenNoContract_ThenAllocateNothing": {
+			givenAllocations: []model.Allocation{{Type: legal2022, Days: 25, State: StateApproved}},
+			expectedBalances: []VacationBalance{{LeaveType: "Legal Leaves 2022"}},
+		},
+		"GivenNoAllocation_ThenReturnEmpty": {
+			givenAllocations: []model.Allocation{{Type: legal2021, Days: 25, State: StateApproved}},
+			givenContracts:   []model.Contract{fullTime},
+			expectedFTE:      1,
+			expectedBalances: []VacationBalance{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			allocations := odoo.List[model.Allocation]{Items: tc.givenAllocations}
+			leaves := odoo.List[model.Leave]{Items: tc.givenLeaves}
+			contracts := model.ContractList{Items: tc.givenContracts}
+			result := NewVacationReporter(allocations, leaves, model.Employee{Name: "Jane"}, contracts).
+				SetYear(2022).SetTimeZone(zurichTZ).
+				CalculateVacationReport()
+			assert.InDelta(t, tc.expectedFTE, result.FTERatio, 0.0001)
+			require.Len(t, result.Balances, len(tc.expectedBalances))
+			for i, expected := range tc.expectedBalances {
+				actual := result.Balances[i]
+				assert.Equal(t, expected.LeaveType, actual.LeaveType)
+				assert.InDelta(t, expected.Allocated, actual.Allocated, 0.0001, "allocated")
+				assert.Equal(t, expected.Taken, actual.Taken, "taken")
+				assert.Equal(t, expected.Pending, actual.Pending, "pending")
+				assert.InDelta(t, expected.Remaining, actual.Remaining, 0.0001, "remaining")
+			}
+		})
+	}
+}
+
+func TestLeaveTypesOfYear(t *testing.T) {
+	allocations := odoo.List[model.Allocation]{Items: []model.Allocation{
+		{Type: &model.LeaveType{ID: 17, Name: "Legal Leaves 2021"}},
+		{Type: &model.LeaveType{ID: 18, Name: "Legal Leaves 2022"}},
+		{Type: &model.LeaveType{ID: 18, Name: "Legal Leaves 2022"}},
+		{Type: &model.LeaveType{ID: 20, Name: "Compensation 2022"}},
+		{Type: nil},
+	}}
+	result := LeaveTypesOfYear(allocations, 2022)
+	assert.Equal(t, []*model.LeaveType{{ID: 18, Name: "Legal Leaves 2022"}, {ID: 20, Name: "Compensation 2022"}}, result)
+}
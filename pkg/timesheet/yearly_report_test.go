@@ -35,7 +35,7 @@ func TestYearlyReportBuilder_CalculateYearlyReport(t *testing.T) {
 	}}
 	givenEmployee := model.Employee{Name: "🕺"}
 	givenContracts := model.ContractList{Items: []model.Contract{
-		{Start: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Name: "100%"}},
+		{Start: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), WorkingSchedule: &model.WorkingSchedule{Many2One: odoo.Many2One{Name: "100%"}}},
 	}}
 	givenPayslips := model.PayslipList{Items: []model.Payslip{
 		{DateFrom: odoo.NewDate(2021, 01, 01, 0, 0, 0, time.UTC), DateTo: odoo.NewDate(2021, 02, 01, 0, 0, 0, time.UTC), XOvertime: "10:00:00"},
@@ -18,9 +18,22 @@ type BaseController struct {
 
 const HRManagerRoleKey = "HRManager"
 
+// BypassCacheParam is the query parameter that forces fresh data from Odoo instead of cached results, e.g. `/report/employees/2022/03?refresh=true`.
+const BypassCacheParam = "refresh"
+
 // SessionData is an additional data struct.
 // Its purpose is to store data in a session cookie in order to avoid repetitive Odoo API calls.
 type SessionData struct {
 	Employee *model.Employee `json:"employee"`
 	Roles    []string        `json:"roles"`
 }
+
+// HasRole returns true if the user has the given role, e.g. HRManagerRoleKey.
+func (d SessionData) HasRole(role string) bool {
+	for _, r := range d.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
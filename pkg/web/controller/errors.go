@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vshn/odootools/pkg/odoo"
+)
+
+// ErrForbidden is returned if the logged-in user isn't allowed to perform an action in odootools, regardless of the access rights in Odoo.
+var ErrForbidden = errors.New("you are not allowed to perform this action")
+
+// ErrBadRequest is returned if the user input is invalid, e.g. a malformed date in the URL.
+var ErrBadRequest = errors.New("invalid input")
+
+// HTTPStatusOf returns the HTTP status code that represents the given error.
+// Errors returned by Odoo are mapped to
+//   - 400 Bad Request if the error is ErrBadRequest,
+//   - 403 Forbidden if the user lacks access rights or the error is ErrForbidden,
+//   - 422 Unprocessable Entity if a field is missing or the data is invalid,
+//   - 502 Bad Gateway for any other Odoo server exception.
+//
+// The given default status is returned for all other errors.
+func HTTPStatusOf(err error, defaultStatus int) int {
+	switch {
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	case odoo.IsAccessDenied(err), errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case odoo.IsMissingField(err), odoo.IsValidationError(err):
+		return http.StatusUnprocessableEntity
+	}
+	rpcErr := &odoo.RPCError{}
+	if errors.As(err, &rpcErr) {
+		return http.StatusBadGateway
+	}
+	return defaultStatus
+}
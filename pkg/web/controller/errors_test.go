@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vshn/odootools/pkg/odoo"
+)
+
+func TestHTTPStatusOf(t *testing.T) {
+	tests := map[string]struct {
+		givenError     error
+		expectedStatus int
+	}{
+		"GivenAccessError_ThenReturnForbidden": {
+			givenError:     &odoo.RPCError{Code: 200, Name: "openerp.exceptions.AccessError", Message: "access denied"},
+			expectedStatus: http.StatusForbidden,
+		},
+		"GivenWrappedAccessError_ThenReturnForbidden": {
+			givenError:     fmt.Errorf("step failed: %w", &odoo.RPCError{Code: 200, Name: "odoo.exceptions.AccessError"}),
+			expectedStatus: http.StatusForbidden,
+		},
+		"GivenErrForbidden_ThenReturnForbidden": {
+			givenError:     fmt.Errorf("cannot edit attendances: %w", ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+		},
+		"GivenErrBadRequest_ThenReturnBadRequest": {
+			givenError:     fmt.Errorf("%w: cannot parse date", ErrBadRequest),
+			expectedStatus: http.StatusBadRequest,
+		},
+		"GivenInvalidField_ThenReturnUnprocessableEntity": {
+			givenError:     &odoo.RPCError{Code: 200, Name: "exceptions.ValueError", Message: "Invalid field 'foo' in leaf \"<osv.ExtendedLeaf: ('foo', '=', 1) on hr_employee (ctx: )>\""},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		"GivenValidationError_ThenReturnUnprocessableEntity": {
+			givenError:     &odoo.RPCError{Code: 200, Name: "openerp.exceptions.ValidationError", Message: "Error while validating constraint"},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		"GivenOdoo8ExceptOrm_ThenReturnBadGateway": {
+			givenError:     &odoo.RPCError{Code: 200, Name: "openerp.osv.orm.except_orm", Message: "Access Denied"},
+			expectedStatus: http.StatusBadGateway,
+		},
+		"GivenOtherOdooError_ThenReturnBadGateway": {
+			givenError:     &odoo.RPCError{Code: 200, Name: "exceptions.TypeError", Message: "unhashable type"},
+			expectedStatus: http.StatusBadGateway,
+		},
+		"GivenOtherError_ThenReturnDefault": {
+			givenError:     errors.New("no contract found"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedStatus, HTTPStatusOf(tc.givenError, http.StatusInternalServerError))
+		})
+	}
+}
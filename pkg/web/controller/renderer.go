@@ -22,7 +22,7 @@ func NewRenderer() *Renderer {
 }
 
 // Render renders the requested template with the given data into w.
-// "template" is suffixed with ".html", and then rendered together with "layout.html".
+// "template" is suffixed with ".html", and then rendered together with "layout.html" and the partials "nav.html" and "findings.html".
 func (v *Renderer) Render(w io.Writer, name string, data interface{}, c echo.Context) error {
 	tpl, err := v.getTemplate(name)
 	if err != nil {
@@ -33,7 +33,7 @@ func (v *Renderer) Render(w io.Writer, name string, data interface{}, c echo.Con
 
 func (v *Renderer) getTemplate(name string) (*template.Template, error) {
 	if v.cache[name] == nil {
-		t, err := template.ParseFS(templates.TemplateFS, "layout.html", name+".html", "nav.html")
+		t, err := template.ParseFS(templates.TemplateFS, "layout.html", name+".html", "nav.html", "findings.html")
 		if err != nil {
 			return nil, err
 		}
@@ -70,10 +70,38 @@ func (v BaseView) FormatDailySummary(daily *timesheet.DailySummary) Values {
 	}
 	if daily.HasAbsences() {
 		basic["LeaveType"] = daily.Absences[0].Reason
+		if leaveDays := daily.CalculateLeaveDays(); leaveDays > 0 && leaveDays < 1 {
+			// partial leave, e.g. half-day
+			basic["LeaveType"] = fmt.Sprintf("%s (%sd)", daily.Absences[0].Reason, v.FormatFloat(leaveDays, 2))
+		}
 	}
 	return basic
 }
 
+// FormatFindings returns Values for the findings of the given day, with a form per fix that applies the fix in Odoo.
+// The findings are expected to be returned by timesheet.Fixer, since fixes are identified by their index.
+func (v BaseView) FormatFindings(employeeID int, date time.Time, findings []timesheet.Finding) []Values {
+	formatted := make([]Values, len(findings))
+	for i, finding := range findings {
+		fixes := make([]Values, len(finding.Fixes))
+		for j, fix := range finding.Fixes {
+			fixes[j] = Values{
+				"Description": fix.String(),
+				"Finding":     i,
+				"Fix":         j,
+			}
+		}
+		formatted[i] = Values{
+			"Message":    finding.Error(),
+			"Kind":       finding.Kind,
+			"Severity":   finding.Severity,
+			"FormAction": fmt.Sprintf("/report/%d/%s", employeeID, date.Format("2006/01/02")),
+			"Fixes":      fixes,
+		}
+	}
+	return formatted
+}
+
 func (v BaseView) OvertimeClassname(duration time.Duration) string {
 	overtimeClassname := ""
 	if duration == 0 {
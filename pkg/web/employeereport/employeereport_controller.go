@@ -8,6 +8,7 @@ import (
 
 	pipeline "github.com/ccremer/go-command-pipeline"
 	"github.com/hashicorp/go-multierror"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/pkg/odoo"
 	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/web/controller"
@@ -39,12 +40,12 @@ func NewEmployeeReportController(ctx *controller.BaseController) *ReportControll
 func (c *ReportController) DisplayEmployeeReport() error {
 	root := pipeline.NewPipeline[context.Context]()
 	root.WithOptions(pipeline.Options{DisableErrorWrapping: true}).
-		WithSteps(
+		WithSteps(metrics.InstrumentSteps("employee report",
 			root.NewStep("parse user input", c.parseInput),
 			root.NewStep("fetch employees", c.fetchEmployees),
 			pipeline.NewWorkerPoolStep("generate reports for each employee", 4, c.createPipelinesForEachEmployee, c.collectReports),
 			root.NewStep("render report", c.renderReport),
-		)
+		)...)
 	err := root.RunWithContext(c.RequestContext)
 	return err
 }
@@ -73,10 +74,10 @@ func (c *ReportController) createPipelinesForEachEmployee(ctx context.Context, p
 
 func (c *EmployeeReport) createPipeline() *pipeline.Pipeline[context.Context] {
 	p := pipeline.NewPipeline[context.Context]()
-	p.AddStep(p.WithNestedSteps(fmt.Sprintf("report for %q", c.MonthlyReportController.Employee.Name), nil,
+	p.AddStep(p.WithNestedSteps(fmt.Sprintf("report for %q", c.MonthlyReportController.Employee.Name), nil, metrics.InstrumentSteps("employee report per employee",
 		p.NewStep("fetch data", c.MonthlyReportController.FetchReportData),
 		p.NewStep("calculate monthly report", c.MonthlyReportController.CalculateMonthlyReport).WithErrorHandler(c.ignoreNoContractFound),
-	))
+	)...))
 	return p
 }
 
@@ -98,14 +99,7 @@ func (c *ReportController) parseInput(_ context.Context) error {
 }
 
 func (c *ReportController) fetchEmployees(ctx context.Context) error {
-	list := odoo.List[model.Employee]{}
-	err := c.OdooSession.SearchGenericModel(ctx, odoo.SearchReadModel{
-		Model: "hr.employee",
-		Domain: []odoo.Filter{
-			[]string{"work_email", "ilike", "@vshn.ch"},
-		},
-		Fields: []string{"name"},
-	}, &list)
+	list, err := c.OdooClient.FetchAllEmployees(ctx)
 	c.employees = list
 	return err
 }
@@ -33,6 +33,7 @@ func (v *reportView) GetValuesForReports(reports []*EmployeeReport, failedEmploy
 			"PreviousMonthLink": fmt.Sprintf(linkFormat, prevYear, prevMonth),
 			"NextMonthLink":     fmt.Sprintf(linkFormat, nextYear, nextMonth),
 			"CurrentMonthLink":  fmt.Sprintf(linkFormat, time.Now().Year(), time.Now().Month()),
+			"RefreshLink":       fmt.Sprintf(linkFormat+"?%s=true", v.year, v.month, controller.BypassCacheParam),
 		},
 		"Reports":       reportValues,
 		"Warning":       v.formatErrorForFailedEmployeeReports(failedEmployees),
@@ -49,8 +50,11 @@ func (v *reportView) getValuesForReport(report timesheet.Report, previousPayslip
 	nextBalanceCellText, nextBalance := v.getNextBalance(proposedBalance, nextPayslip)
 	overtimeBalanceEditPreview := v.getOvertimeBalanceEditPreview(nextPayslip, nextBalance)
 	validationErrorList := &timesheet.ValidationErrorList{}
+	findings := make([]controller.Values, 0)
+	fixer := timesheet.NewFixer(report)
 	for _, summary := range report.DailySummaries {
 		timesheet.AppendValidationError(validationErrorList, summary.ValidateTimesheetEntries())
+		findings = append(findings, v.FormatFindings(report.Employee.ID, summary.Date, fixer.Findings(summary.Date))...)
 	}
 	return controller.Values{
 		"Name":                            report.Employee.Name,
@@ -74,6 +78,7 @@ func (v *reportView) getValuesForReport(report timesheet.Report, previousPayslip
 		"OvertimeBalanceEditEnabled":      nextPayslip != nil,
 		"OvertimeBalanceEditPreviewValue": overtimeBalanceEditPreview,
 		"ValidationError":                 validationErrorList.Error(),
+		"Findings":                        findings,
 	}
 }
 
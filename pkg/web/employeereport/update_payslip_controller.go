@@ -2,10 +2,13 @@ package employeereport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
+	"github.com/vshn/odootools/pkg/odoo"
 	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/web/controller"
 )
@@ -26,12 +29,12 @@ func NewUpdatePayslipController(ctx *controller.BaseController) *UpdatePayslipCo
 // UpdatePayslipOfEmployee POST /report/employee/:employee/:year/:month
 func (c *UpdatePayslipController) UpdatePayslipOfEmployee() error {
 	root := pipeline.NewPipeline[context.Context]()
-	root.WithSteps(
+	root.WithSteps(metrics.InstrumentSteps("update payslip",
 		root.NewStep("parse user input", c.parseInput).WithErrorHandler(c.serverError(http.StatusBadRequest)),
 		root.NewStep("fetch employee", c.fetchEmployeeByID).WithErrorHandler(c.serverError(http.StatusBadRequest)),
 		root.NewStep("fetch current month's payslip", c.fetchNextPayslip).WithErrorHandler(c.serverError(http.StatusBadRequest)),
 		root.NewStep("save payslip", c.savePayslip).WithErrorHandler(c.serverError(http.StatusInternalServerError)),
-	)
+	)...)
 	err := root.RunWithContext(c.RequestContext)
 	return err
 }
@@ -87,8 +90,12 @@ func (c *UpdatePayslipController) savePayslip(ctx context.Context) error {
 
 func (c *UpdatePayslipController) serverError(httpStatusError int) func(_ context.Context, err error) error {
 	return func(_ context.Context, err error) error {
+		if errors.Is(err, odoo.ErrSessionExpired) {
+			// the caller has to clear the session first
+			return err
+		}
 		if err != nil {
-			jsonErr := c.Echo.JSON(httpStatusError, UpdateResponse{ErrorMessage: err.Error()})
+			jsonErr := c.Echo.JSON(controller.HTTPStatusOf(err, httpStatusError), UpdateResponse{ErrorMessage: err.Error()})
 			if jsonErr != nil {
 				return jsonErr
 			}
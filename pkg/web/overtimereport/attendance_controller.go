@@ -0,0 +1,259 @@
+package overtimereport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+// AttendanceController shows and changes the attendances of a single day of the monthly report.
+type AttendanceController struct {
+	MonthlyReportController
+	Request AttendanceRequest
+	View    *attendanceView
+	// Reasons contains the action reasons by action.
+	Reasons map[string]odoo.List[model.ActionReason]
+	// Fix contains the requested changes.
+	Fix *timesheet.Fix
+	// Preview is the monthly report with the Fix applied.
+	Preview *timesheet.BalanceReport
+	// FormError is a problem with the Fix, which is shown in the form instead of the error page.
+	FormError error
+	saved     bool
+}
+
+func NewAttendanceController(ctx controller.BaseController) *AttendanceController {
+	return &AttendanceController{
+		MonthlyReportController: *NewMonthlyReportController(ctx),
+		View:                    &attendanceView{},
+	}
+}
+
+// DisplayAttendanceForm GET /report/:employee/:year/:month/:day
+func (c *AttendanceController) DisplayAttendanceForm() error {
+	root := pipeline.NewPipeline[context.Context]()
+	root.WithSteps(metrics.InstrumentSteps("attendance form",
+		root.NewStep("parse user input", c.parseAttendanceInput),
+		root.NewStep("check permission", c.checkPermission),
+		root.NewStep("fetch employee", c.FetchEmployeeByID),
+		root.NewStep("fetch data", c.FetchReportData),
+		root.NewStep("check settlement", c.checkSettlement),
+		root.NewStep("fetch reasons", c.fetchReasons),
+		root.NewStep("calculate monthly report", c.CalculateMonthlyReport),
+		root.NewStep("render form", c.renderForm),
+	)...)
+	return root.RunWithContext(c.RequestContext)
+}
+
+// ProcessAttendanceChange POST /report/:employee/:year/:month/:day
+// It renders a preview of the change, or saves it in Odoo if confirmed and redirects to the monthly report.
+func (c *AttendanceController) ProcessAttendanceChange() error {
+	root := pipeline.NewPipeline[context.Context]()
+	root.WithSteps(metrics.InstrumentSteps("attendance change",
+		root.NewStep("parse user input", c.parseAttendanceInput),
+		root.NewStep("check permission", c.checkPermission),
+		root.NewStep("fetch employee", c.FetchEmployeeByID),
+		root.NewStep("fetch data", c.FetchReportData),
+		root.NewStep("check settlement", c.checkSettlement),
+		root.NewStep("fetch reasons", c.fetchReasons),
+		root.NewStep("calculate monthly report", c.CalculateMonthlyReport),
+		root.NewStep("calculate preview", c.calculatePreview),
+		root.When(c.isConfirmed, "save change", c.saveChange),
+		root.When(c.isNotSaved, "render form", c.renderForm),
+	)...)
+	return root.RunWithContext(c.RequestContext)
+}
+
+func (c *AttendanceController) parseAttendanceInput(_ context.Context) error {
+	input := AttendanceRequest{}
+	err := input.FromRequest(c.Echo)
+	c.Request = input
+	c.Input = input.ReportRequest
+	return err
+}
+
+// checkPermission allows employees to change their own attendances, and HR managers to change attendances of everyone.
+func (c *AttendanceController) checkPermission(_ context.Context) error {
+	if c.SessionData.HasRole(controller.HRManagerRoleKey) {
+		return nil
+	}
+	if c.SessionData.Employee != nil && c.SessionData.Employee.ID == c.Input.EmployeeID {
+		return nil
+	}
+	return fmt.Errorf("cannot change attendances of employee %d: %w", c.Input.EmployeeID, controller.ErrForbidden)
+}
+
+// checkSettlement allows only HR managers to change attendances of days that are covered by a payslip with overtime, since the overtime has been paid out or carried over already.
+func (c *AttendanceController) checkSettlement(_ context.Context) error {
+	if c.SessionData.HasRole(controller.HRManagerRoleKey) {
+		return nil
+	}
+	date := c.Request.GetDate(c.getTimeZone())
+	if payslip := c.Payslips.FilterInMonth(date); payslip != nil && payslip.Overtime() != "" {
+		return fmt.Errorf("cannot change attendances of %s, they have been settled with payslip %q: %w", date.Format(odoo.DateFormat), payslip.Name, controller.ErrForbidden)
+	}
+	return nil
+}
+
+func (c *AttendanceController) fetchReasons(ctx context.Context) error {
+	c.Reasons = map[string]odoo.List[model.ActionReason]{}
+	for _, action := range []string{model.ActionSignIn, model.ActionSignOut} {
+		reasons, err := c.OdooClient.FetchActionReasons(ctx, action)
+		if err != nil {
+			return err
+		}
+		c.Reasons[action] = reasons
+	}
+	return nil
+}
+
+func (c *AttendanceController) calculatePreview(_ context.Context) error {
+	fix, err := c.Request.NewFix(c.getTimeZone(), c.Reasons, c.BalanceReport.Report)
+	if err != nil {
+		c.FormError = err
+		return nil
+	}
+	c.Fix = &fix
+	if err := c.checkChangedAttendances(fix); err != nil {
+		c.FormError = err
+		return nil
+	}
+	attendances, err := fix.Apply(c.Attendances)
+	if err != nil {
+		c.FormError = err
+		return nil
+	}
+	preview, err := c.calculateBalanceReport(attendances)
+	if err != nil {
+		return err
+	}
+	c.Preview = &preview
+	c.FormError = c.validatePreview()
+	return nil
+}
+
+// checkChangedAttendances verifies that the attendances to update or delete belong to the requested day.
+// The attendances of the whole month are fetched, so that Apply would find attendances of other days as well.
+func (c *AttendanceController) checkChangedAttendances(fix timesheet.Fix) error {
+	day := c.getDailySummary(c.BalanceReport)
+	for _, change := range fix.Changes {
+		if change.Operation == timesheet.OperationCreate {
+			continue
+		}
+		if day == nil || !hasAttendance(day, change.Attendance) {
+			return fmt.Errorf("%s with ID %d doesn't belong to %s", change.Attendance.Action, change.Attendance.ID, c.Request.GetDate(c.getTimeZone()).Format(odoo.DateFormat))
+		}
+	}
+	return nil
+}
+
+// hasAttendance returns true if one of the shifts of the day starts or ends with the given attendance.
+func hasAttendance(day *timesheet.DailySummary, attendance model.Attendance) bool {
+	for _, shift := range day.Shifts {
+		for _, candidate := range []model.Attendance{shift.Start, shift.End} {
+			if candidate.ID != 0 && candidate.ID == attendance.ID && candidate.Action == attendance.Action {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validatePreview applies the validation rules of the report to the changed day.
+// Changes that make a valid day invalid are rejected.
+// Days that are already invalid may need multiple changes to become valid again, so they're not rejected if they stay invalid.
+func (c *AttendanceController) validatePreview() error {
+	before := c.getDailySummary(c.BalanceReport)
+	after := c.getDailySummary(*c.Preview)
+	if after == nil {
+		return nil
+	}
+	if err := after.ValidateTimesheetEntries(); err != nil && (before == nil || before.ValidateTimesheetEntries() == nil) {
+		return fmt.Errorf("the change would make the timesheet invalid: %w", err)
+	}
+	return nil
+}
+
+func (c *AttendanceController) saveChange(ctx context.Context) error {
+	if c.FormError != nil {
+		return nil
+	}
+	for i, change := range c.Fix.Changes {
+		err := c.saveAttendanceChange(ctx, change)
+		if err != nil && i > 0 {
+			// Odoo doesn't support transactions over multiple calls.
+			err = fmt.Errorf("only the first %d of %d changes have been saved: %w", i, len(c.Fix.Changes), err)
+		}
+		if odoo.IsValidationError(err) {
+			// e.g. Odoo 8 requires that sign_in and sign_out alternate.
+			c.FormError = err
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	c.saved = true
+	return c.Echo.Redirect(http.StatusFound, fmt.Sprintf("/report/%d/%d/%02d", c.Employee.ID, c.Input.Year, c.Input.Month))
+}
+
+func (c *AttendanceController) saveAttendanceChange(ctx context.Context, change timesheet.AttendanceChange) error {
+	switch change.Operation {
+	case timesheet.OperationCreate:
+		_, err := c.OdooClient.CreateAttendance(ctx, c.Employee.ID, change.Attendance)
+		return err
+	case timesheet.OperationUpdate:
+		return c.OdooClient.UpdateAttendance(ctx, change.Attendance)
+	case timesheet.OperationDelete:
+		return c.OdooClient.DeleteAttendance(ctx, change.Attendance)
+	}
+	return fmt.Errorf("unknown operation: %q", change.Operation)
+}
+
+func (c *AttendanceController) renderForm(_ context.Context) error {
+	status := http.StatusOK
+	if c.FormError != nil && c.Request.Confirm {
+		status = http.StatusUnprocessableEntity
+	}
+	form := attendanceForm{
+		Date:      c.Request.GetDate(c.getTimeZone()),
+		Report:    c.BalanceReport,
+		Day:       c.getDailySummary(c.BalanceReport),
+		Reasons:   c.Reasons[model.ActionSignIn],
+		Request:   c.Request,
+		Fix:       c.Fix,
+		Preview:   c.Preview,
+		FormError: c.FormError,
+	}
+	if c.Preview != nil {
+		form.PreviewDay = c.getDailySummary(*c.Preview)
+	}
+	values := c.View.GetValuesForAttendanceForm(form)
+	return c.Echo.Render(status, attendanceFormTemplateName, values)
+}
+
+// getDailySummary returns the summary of the requested day, or nil if the report doesn't contain the day, e.g. because it's in the future.
+func (c *AttendanceController) getDailySummary(report timesheet.BalanceReport) *timesheet.DailySummary {
+	date := c.Request.GetDate(c.getTimeZone())
+	for _, daily := range report.Report.DailySummaries {
+		if daily.Date.Equal(date) {
+			return daily
+		}
+	}
+	return nil
+}
+
+func (c *AttendanceController) isConfirmed(_ context.Context) bool {
+	return c.Request.Confirm
+}
+
+func (c *AttendanceController) isNotSaved(_ context.Context) bool {
+	return !c.saved
+}
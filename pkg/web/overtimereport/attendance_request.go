@@ -0,0 +1,129 @@
+package overtimereport
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/reportconfig"
+)
+
+// OperationFix applies a correction suggested by timesheet.Fixer.
+const OperationFix = "fix"
+
+// AttendanceRequest contains the input of the attendance form of a single day.
+type AttendanceRequest struct {
+	reportconfig.ReportRequest
+	Day int `param:"day"`
+
+	// Operation is one of timesheet.OperationCreate, timesheet.OperationUpdate, timesheet.OperationDelete or OperationFix.
+	Operation    string `form:"operation"`
+	AttendanceID int    `form:"id"`
+	// Action is either model.ActionSignIn or model.ActionSignOut.
+	Action string `form:"action"`
+	// Time is the local time of the attendance in the format "15:04".
+	Time string `form:"time"`
+	// Reason is the name of the action reason, empty if none.
+	Reason string `form:"reason"`
+	// Kind, Finding and Fix identify the correction of OperationFix.
+	// Finding and Fix are the indexes in the findings of the day and their fixes, Kind is the kind of the finding to detect outdated requests.
+	Kind    string `form:"kind"`
+	Finding int    `form:"finding"`
+	Fix     int    `form:"fix"`
+	// Confirm is true if the change should be saved in Odoo, otherwise only a preview is shown.
+	Confirm bool `form:"confirm"`
+}
+
+// FromRequest parses the properties based on the given request echo.Context.
+func (i *AttendanceRequest) FromRequest(e echo.Context) error {
+	if err := i.ReportRequest.FromRequest(e); err != nil {
+		return err
+	}
+	if err := e.Bind(i); err != nil {
+		return err
+	}
+	if time.Date(i.Year, time.Month(i.Month), i.Day, 0, 0, 0, 0, time.UTC).Day() != i.Day {
+		return fmt.Errorf("invalid date: %d-%02d-%02d", i.Year, i.Month, i.Day)
+	}
+	return nil
+}
+
+// GetDate returns the requested day at midnight in the given location.
+func (i AttendanceRequest) GetDate(tz *time.Location) time.Time {
+	return time.Date(i.Year, time.Month(i.Month), i.Day, 0, 0, 0, 0, tz)
+}
+
+// NewAttendanceChange returns the change described by the request.
+// The reasons contain the reasons that can be given per action, see model.Odoo.FetchActionReasons.
+func (i AttendanceRequest) NewAttendanceChange(tz *time.Location, reasons map[string]odoo.List[model.ActionReason]) (timesheet.AttendanceChange, error) {
+	change := timesheet.AttendanceChange{Operation: i.Operation, Attendance: model.Attendance{ID: i.AttendanceID, Action: i.Action}}
+	switch i.Operation {
+	case timesheet.OperationCreate:
+	case timesheet.OperationUpdate, timesheet.OperationDelete:
+		if i.AttendanceID <= 0 {
+			return change, fmt.Errorf("%s requires an attendance", i.Operation)
+		}
+	default:
+		return change, fmt.Errorf("unknown operation: %q", i.Operation)
+	}
+	if i.Action != model.ActionSignIn && i.Action != model.ActionSignOut {
+		return change, fmt.Errorf("action has to be %s or %s", model.ActionSignIn, model.ActionSignOut)
+	}
+	if i.Operation == timesheet.OperationDelete {
+		return change, nil
+	}
+	tm, err := time.ParseInLocation("15:04", i.Time, tz)
+	if err != nil {
+		return change, fmt.Errorf("time has to be in the format 'hh:mm': %q", i.Time)
+	}
+	day := i.GetDate(tz)
+	change.Attendance.DateTime = odoo.Date{Time: time.Date(day.Year(), day.Month(), day.Day(), tm.Hour(), tm.Minute(), 0, 0, tz)}
+	change.Attendance.Reason, err = findReason(reasons, i.Action, i.Reason)
+	return change, err
+}
+
+// NewFix returns the correction described by the request.
+// For OperationFix, it's the fix suggested by timesheet.Fixer for the requested day of the given report, otherwise it's the single change of NewAttendanceChange.
+func (i AttendanceRequest) NewFix(tz *time.Location, reasons map[string]odoo.List[model.ActionReason], report timesheet.Report) (timesheet.Fix, error) {
+	if i.Operation != OperationFix {
+		change, err := i.NewAttendanceChange(tz, reasons)
+		return timesheet.Fix{Changes: []timesheet.AttendanceChange{change}}, err
+	}
+	findings := timesheet.NewFixer(report).Findings(i.GetDate(tz))
+	if i.Finding < 0 || i.Finding >= len(findings) || string(findings[i.Finding].Kind) != i.Kind ||
+		i.Fix < 0 || i.Fix >= len(findings[i.Finding].Fixes) {
+		return timesheet.Fix{}, errors.New("the suggested fix doesn't apply anymore, the attendances may have been changed in the meantime")
+	}
+	suggested := findings[i.Finding].Fixes[i.Fix]
+	// Suggested changes only contain the names of reasons.
+	fix := timesheet.Fix{Description: suggested.Description, Changes: make([]timesheet.AttendanceChange, len(suggested.Changes))}
+	for j, change := range suggested.Changes {
+		if change.Operation != timesheet.OperationDelete {
+			reason, err := findReason(reasons, change.Attendance.Action, change.Attendance.Reason.String())
+			if err != nil {
+				return fix, err
+			}
+			change.Attendance.Reason = reason
+		}
+		fix.Changes[j] = change
+	}
+	return fix, nil
+}
+
+// findReason returns the reason with the given name that can be given to attendances with the given action, or nil if the name is empty.
+func findReason(reasons map[string]odoo.List[model.ActionReason], action, name string) (*model.ActionReason, error) {
+	if name == "" {
+		return nil, nil
+	}
+	for _, reason := range reasons[action].Items {
+		if reason.Name == name {
+			r := reason
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("reason %q doesn't exist for %s", name, action)
+}
@@ -0,0 +1,144 @@
+package overtimereport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/reportconfig"
+)
+
+var zurichTZ *time.Location
+
+func init() {
+	zue, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		panic(err)
+	}
+	zurichTZ = zue
+}
+
+func newAttendanceRequest(operation string, id int, action, tm, reason string) AttendanceRequest {
+	return AttendanceRequest{
+		ReportRequest: reportconfig.ReportRequest{BaseReportRequest: reportconfig.BaseReportRequest{Year: 2022, Month: 3}},
+		Day:           2,
+		Operation:     operation,
+		AttendanceID:  id,
+		Action:        action,
+		Time:          tm,
+		Reason:        reason,
+	}
+}
+
+func TestAttendanceRequest_NewAttendanceChange(t *testing.T) {
+	reasons := map[string]odoo.List[model.ActionReason]{
+		model.ActionSignIn:  {Items: []model.ActionReason{{ID: 1, Name: "Outside office hours"}}},
+		model.ActionSignOut: {Items: []model.ActionReason{{ID: 2, Name: "Outside office hours"}}},
+	}
+	tests := map[string]struct {
+		givenRequest   AttendanceRequest
+		expectedChange timesheet.AttendanceChange
+		expectedError  string
+	}{
+		"GivenCreate_ThenReturnLocalTime": {
+			givenRequest: newAttendanceRequest(timesheet.OperationCreate, 0, model.ActionSignOut, "17:30", ""),
+			expectedChange: timesheet.AttendanceChange{Operation: timesheet.OperationCreate, Attendance: model.Attendance{
+				Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 17, 30, 0, zurichTZ),
+			}},
+		},
+		"GivenReason_ThenUseReasonOfAction": {
+			givenRequest: newAttendanceRequest(timesheet.OperationUpdate, 4, model.ActionSignOut, "22:00", "Outside office hours"),
+			expectedChange: timesheet.AttendanceChange{Operation: timesheet.OperationUpdate, Attendance: model.Attendance{
+				ID: 4, Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 22, 0, 0, zurichTZ),
+				Reason: &model.ActionReason{ID: 2, Name: "Outside office hours"},
+			}},
+		},
+		"GivenDelete_ThenIgnoreTime": {
+			givenRequest:   newAttendanceRequest(timesheet.OperationDelete, 4, model.ActionSignIn, "", ""),
+			expectedChange: timesheet.AttendanceChange{Operation: timesheet.OperationDelete, Attendance: model.Attendance{ID: 4, Action: model.ActionSignIn}},
+		},
+		"GivenUnknownReason_ThenReturnError": {
+			givenRequest:  newAttendanceRequest(timesheet.OperationCreate, 0, model.ActionSignIn, "08:00", "Coffee"),
+			expectedError: `reason "Coffee" doesn't exist for sign_in`,
+		},
+		"GivenInvalidTime_ThenReturnError": {
+			givenRequest:  newAttendanceRequest(timesheet.OperationCreate, 0, model.ActionSignIn, "8 o'clock", ""),
+			expectedError: `time has to be in the format 'hh:mm': "8 o'clock"`,
+		},
+		"GivenUpdateWithoutID_ThenReturnError": {
+			givenRequest:  newAttendanceRequest(timesheet.OperationUpdate, 0, model.ActionSignIn, "08:00", ""),
+			expectedError: "update requires an attendance",
+		},
+		"GivenInvalidAction_ThenReturnError": {
+			givenRequest:  newAttendanceRequest(timesheet.OperationCreate, 0, "sign_around", "08:00", ""),
+			expectedError: "action has to be sign_in or sign_out",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := tc.givenRequest.NewAttendanceChange(zurichTZ, reasons)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChange, result)
+		})
+	}
+}
+
+func TestAttendanceRequest_NewFix(t *testing.T) {
+	reasons := map[string]odoo.List[model.ActionReason]{
+		model.ActionSignIn:  {Items: []model.ActionReason{{ID: 1, Name: "Outside office hours"}}},
+		model.ActionSignOut: {Items: []model.ActionReason{{ID: 2, Name: "Outside office hours"}}},
+	}
+	day := timesheet.NewDailySummary(1, time.Date(2022, time.March, 2, 0, 0, 0, 0, zurichTZ))
+	day.Shifts = []timesheet.AttendanceShift{{
+		Start: model.Attendance{ID: 3, Action: model.ActionSignIn, DateTime: odoo.NewDate(2022, time.March, 2, 20, 0, 0, zurichTZ), Reason: &model.ActionReason{ID: 1, Name: "Outside office hours"}},
+		End:   model.Attendance{ID: 4, Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 22, 0, 0, zurichTZ)},
+	}}
+	report := timesheet.Report{DailySummaries: []*timesheet.DailySummary{day}}
+	tests := map[string]struct {
+		givenKind     string
+		givenFinding  int
+		givenFix      int
+		expectedFix   timesheet.Fix
+		expectedError string
+	}{
+		"GivenSuggestedFix_ThenResolveReasonOfAction": {
+			givenKind: string(timesheet.KindReasonMismatch), givenFinding: 0, givenFix: 0,
+			expectedFix: timesheet.Fix{Description: "Change reason of sign_out to Outside office hours", Changes: []timesheet.AttendanceChange{{
+				Operation: timesheet.OperationUpdate,
+				Attendance: model.Attendance{
+					ID: 4, Action: model.ActionSignOut, DateTime: odoo.NewDate(2022, time.March, 2, 22, 0, 0, zurichTZ),
+					Reason: &model.ActionReason{ID: 2, Name: "Outside office hours"},
+				},
+			}}},
+		},
+		"GivenOtherKind_ThenReturnError": {
+			givenKind: string(timesheet.KindMissingSignOut), givenFinding: 0, givenFix: 0,
+			expectedError: "the suggested fix doesn't apply anymore, the attendances may have been changed in the meantime",
+		},
+		"GivenUnknownFix_ThenReturnError": {
+			givenKind: string(timesheet.KindReasonMismatch), givenFinding: 0, givenFix: 2,
+			expectedError: "the suggested fix doesn't apply anymore, the attendances may have been changed in the meantime",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := newAttendanceRequest(OperationFix, 0, "", "", "")
+			request.Kind, request.Finding, request.Fix = tc.givenKind, tc.givenFinding, tc.givenFix
+			result, err := request.NewFix(zurichTZ, reasons, report)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFix, result)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package overtimereport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+const attendanceFormTemplateName = "attendance-form"
+
+type attendanceView struct {
+	controller.BaseView
+}
+
+// attendanceForm contains the data shown in the attendance form.
+type attendanceForm struct {
+	Date    time.Time
+	Report  timesheet.BalanceReport
+	Day     *timesheet.DailySummary
+	Reasons odoo.List[model.ActionReason]
+	Request AttendanceRequest
+	// Fix, Preview and PreviewDay are nil unless a change has been requested.
+	Fix        *timesheet.Fix
+	Preview    *timesheet.BalanceReport
+	PreviewDay *timesheet.DailySummary
+	FormError  error
+}
+
+func (v *attendanceView) GetValuesForAttendanceForm(form attendanceForm) controller.Values {
+	employee := form.Report.Report.Employee
+	reasons := make([]string, len(form.Reasons.Items))
+	for i, reason := range form.Reasons.Items {
+		reasons[i] = reason.Name
+	}
+	values := controller.Values{
+		"Username":            employee.Name,
+		"DateDisplayName":     fmt.Sprintf("%s, %s", form.Date.Weekday(), form.Date.Format(odoo.DateFormat)),
+		"TimezoneDisplayName": form.Date.Location().String(),
+		"FormAction":          fmt.Sprintf("/report/%d/%d/%02d/%02d", employee.ID, form.Date.Year(), form.Date.Month(), form.Date.Day()),
+		"MonthlyReportLink":   fmt.Sprintf("/report/%d/%d/%02d", employee.ID, form.Date.Year(), form.Date.Month()),
+		"Attendances":         v.formatAttendances(form.Day),
+		"Day":                 v.formatDay(form.Day),
+		"Reasons":             reasons,
+		"FormError":           form.FormError,
+		"Nav": controller.Values{
+			"LoggedIn":   true,
+			"ActiveView": attendanceFormTemplateName,
+		},
+	}
+	if form.Fix != nil && form.Preview != nil {
+		values["Preview"] = controller.Values{
+			"Change":          form.Fix.String(),
+			"Day":             v.formatDay(form.PreviewDay),
+			"OvertimeBefore":  v.FormatDurationInHours(form.Report.Report.Summary.TotalOvertime),
+			"BeforeClassname": v.OvertimeClassname(form.Report.Report.Summary.TotalOvertime),
+			"OvertimeAfter":   v.FormatDurationInHours(form.Preview.Report.Summary.TotalOvertime),
+			"AfterClassname":  v.OvertimeClassname(form.Preview.Report.Summary.TotalOvertime),
+			"CanSave":         form.FormError == nil,
+			"Operation":       form.Request.Operation,
+			"AttendanceID":    form.Request.AttendanceID,
+			"Action":          form.Request.Action,
+			"Time":            form.Request.Time,
+			"Reason":          form.Request.Reason,
+			"Kind":            form.Request.Kind,
+			"Finding":         form.Request.Finding,
+			"Fix":             form.Request.Fix,
+		}
+	}
+	return values
+}
+
+// formatDay returns the formatted summary, or nil if the day isn't part of the report.
+func (v *attendanceView) formatDay(day *timesheet.DailySummary) controller.Values {
+	if day == nil {
+		return nil
+	}
+	return v.FormatDailySummary(day)
+}
+
+// formatAttendances returns the attendances of the day in the order of the shifts.
+func (v *attendanceView) formatAttendances(day *timesheet.DailySummary) []controller.Values {
+	formatted := make([]controller.Values, 0)
+	if day == nil {
+		return formatted
+	}
+	for _, shift := range day.Shifts {
+		for _, attendance := range []model.Attendance{shift.Start, shift.End} {
+			if attendance.DateTime.IsZero() || attendance.ID == 0 {
+				// missing, faked by model.AttendanceList AddCurrentTimeAsSignOut or the midnight of a shift that continues on another day
+				continue
+			}
+			formatted = append(formatted, controller.Values{
+				"ID":     attendance.ID,
+				"Action": attendance.Action,
+				"Time":   attendance.DateTime.Format("15:04"),
+				"Reason": attendance.Reason.String(),
+			})
+		}
+	}
+	return formatted
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/timesheet"
 	"github.com/vshn/odootools/pkg/web/controller"
@@ -14,7 +15,6 @@ import (
 type MonthlyReportController struct {
 	ReportController
 	ReportView    *monthlyReportView
-	User          *model.User
 	Payslips      model.PayslipList
 	BalanceReport timesheet.BalanceReport
 }
@@ -31,44 +31,49 @@ func NewMonthlyReportController(ctx controller.BaseController) *MonthlyReportCon
 // DisplayMonthlyOvertimeReport GET /report/:id/:year/:month
 func (c *MonthlyReportController) DisplayMonthlyOvertimeReport() error {
 	root := pipeline.NewPipeline[context.Context]()
-	root.WithSteps(
-		root.NewStep("parse user input", c.parseInput),
-		root.NewStep("fetch employee", c.fetchEmployeeByID),
+	root.WithSteps(metrics.InstrumentSteps("monthly report",
+		root.NewStep("parse user input", c.ParseInput),
+		root.NewStep("fetch employee", c.FetchEmployeeByID),
 		root.NewStep("fetch data", c.FetchReportData),
 		root.NewStep("calculate monthly report", c.CalculateMonthlyReport),
 		root.NewStep("render report", c.renderReport),
-	)
+	)...)
 	err := root.RunWithContext(c.RequestContext)
 	return err
 }
 
 func (c *MonthlyReportController) FetchReportData(ctx context.Context) error {
 	root := pipeline.NewPipeline[context.Context]()
-	root.WithSteps(
+	root.WithSteps(metrics.InstrumentSteps("monthly report data",
 		root.NewStep("fetch payslips", c.fetchPayslips),
 		root.NewStep("fetch user settings", c.fetchUser),
-		root.NewStep("fetch contracts", c.fetchContracts),
+		root.NewStep("fetch contracts", c.FetchContracts),
 		root.NewStep("fetch attendances", c.fetchAttendances),
 		root.NewStep("fetch leaves", c.fetchLeaves),
-	)
+	)...)
 	err := root.RunWithContext(ctx)
 	return err
 }
 
 func (c *MonthlyReportController) CalculateMonthlyReport(_ context.Context) error {
+	balanceReport, err := c.calculateBalanceReport(c.Attendances)
+	c.BalanceReport = balanceReport
+	return err
+}
+
+// calculateBalanceReport calculates the report of the requested month with the given attendances.
+// The returned report contains at least the timesheet.Report if there's an error, so that the error handler can retrieve the employee name.
+func (c *MonthlyReportController) calculateBalanceReport(attendances model.AttendanceList) (timesheet.BalanceReport, error) {
 	tz := c.getTimeZone()
 	start := time.Date(c.Input.Year, time.Month(c.Input.Month), 1, 0, 0, 0, 0, tz)
 	end := start.AddDate(0, 1, 0)
-	reporter := timesheet.NewReporter(c.Attendances.AddCurrentTimeAsSignOut(tz), c.Leaves, c.Employee, c.Contracts)
+	reporter := timesheet.NewReporter(attendances.AddCurrentTimeAsSignOut(tz), c.Leaves, c.Employee, c.Contracts)
 	report, err := reporter.CalculateReport(start, end)
-	c.BalanceReport.Report = report // needed so that error handler can retrieve employee name
 	if err != nil {
-		return err
+		return timesheet.BalanceReport{Report: report}, err
 	}
 	balanceReporter := timesheet.NewBalanceReportBuilder(report, c.Payslips)
-	balanceReport, err := balanceReporter.CalculateBalanceReport()
-	c.BalanceReport = balanceReport
-	return err
+	return balanceReporter.CalculateBalanceReport()
 }
 
 func (c *MonthlyReportController) renderReport(_ context.Context) error {
@@ -99,12 +104,6 @@ func (c *MonthlyReportController) fetchPayslips(ctx context.Context) error {
 	return err
 }
 
-func (c *MonthlyReportController) fetchUser(ctx context.Context) error {
-	user, err := c.OdooClient.FetchUserByID(ctx, c.OdooSession.UID)
-	c.User = user
-	return err
-}
-
 func (c *MonthlyReportController) GetNextPayslip() *model.Payslip {
 	return c.Payslips.FilterInMonth(time.Date(c.Input.Year, time.Month(c.Input.Month), 2, 0, 0, 0, 0, time.UTC))
 }
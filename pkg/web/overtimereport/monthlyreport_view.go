@@ -17,11 +17,12 @@ type monthlyReportView struct {
 func (v *monthlyReportView) GetValuesForMonthlyReport(report timesheet.BalanceReport) controller.Values {
 	formatted := make([]controller.Values, 0)
 	hasInvalidAttendances := ""
+	fixer := timesheet.NewFixer(report.Report)
 	for _, summary := range report.Report.DailySummaries {
-		if summary.IsWeekend() && summary.CalculateOvertimeSummary().WorkingTime() == 0 {
+		if !summary.IsWorkingDay() && summary.CalculateOvertimeSummary().WorkingTime() == 0 {
 			continue
 		}
-		values := v.FormatDailySummary(summary)
+		values := v.formatDay(report.Report.Employee.ID, summary, fixer)
 		if values["ValidationError"] != nil {
 			hasInvalidAttendances = "Your timesheet contains errors."
 		}
@@ -48,6 +49,29 @@ func (v *monthlyReportView) GetValuesForMonthlyReport(report timesheet.BalanceRe
 	}
 }
 
+// formatDay returns the values of a row in the report, including the link to correct the attendances of the day and its findings.
+func (v *monthlyReportView) formatDay(employeeID int, summary *timesheet.DailySummary, fixer *timesheet.Fixer) controller.Values {
+	values := v.FormatDailySummary(summary)
+	values["EditLink"] = fmt.Sprintf("/report/%d/%s", employeeID, summary.Date.Format("2006/01/02"))
+	values["Findings"] = v.FormatFindings(employeeID, summary.Date, fixer.Findings(summary.Date))
+	values["Continuations"] = v.formatContinuations(summary)
+	return values
+}
+
+// formatContinuations returns a note for each shift of the day that crosses midnight.
+func (v *monthlyReportView) formatContinuations(summary *timesheet.DailySummary) []string {
+	notes := make([]string, 0)
+	for _, shift := range summary.Shifts {
+		switch {
+		case shift.ContinuedFromPreviousDay:
+			notes = append(notes, fmt.Sprintf("Shift continued from previous day until %s", shift.End.DateTime.Format("15:04")))
+		case shift.ContinuesNextDay:
+			notes = append(notes, fmt.Sprintf("Shift from %s continues on next day", shift.Start.DateTime.Format("15:04")))
+		}
+	}
+	return notes
+}
+
 func (v *monthlyReportView) formatMonthlySummary(report timesheet.BalanceReport) controller.Values {
 	s := report.Report.Summary
 	val := controller.Values{
@@ -3,6 +3,7 @@ package overtimereport
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vshn/odootools/pkg/odoo"
 	"github.com/vshn/odootools/pkg/odoo/model"
@@ -14,19 +15,29 @@ type ReportController struct {
 	controller.BaseController
 	Input       reportconfig.ReportRequest
 	Employee    model.Employee
+	User        *model.User
 	Contracts   model.ContractList
 	Attendances model.AttendanceList
 	Leaves      odoo.List[model.Leave]
+	// dateRange replaces the range of Input when fetching attendances and leaves, if set.
+	dateRange dateRange
 }
 
-func (c *ReportController) parseInput(_ context.Context) error {
+// dateRange is implemented by the report requests.
+type dateRange interface {
+	GetDateRange() (time.Time, time.Time)
+}
+
+// ParseInput binds the ReportRequest from the request.
+func (c *ReportController) ParseInput(_ context.Context) error {
 	input := reportconfig.ReportRequest{}
 	err := input.FromRequest(c.Echo)
 	c.Input = input
 	return err
 }
 
-func (c *ReportController) fetchEmployeeByID(ctx context.Context) error {
+// FetchEmployeeByID fetches the employee given in the ReportRequest, unless it's the employee of the logged-in user.
+func (c *ReportController) FetchEmployeeByID(ctx context.Context) error {
 	employeeID := c.Input.EmployeeID
 	if c.SessionData.Employee != nil && c.SessionData.Employee.ID == employeeID {
 		c.Employee = *c.SessionData.Employee
@@ -41,28 +52,40 @@ func (c *ReportController) fetchEmployeeByID(ctx context.Context) error {
 	return err
 }
 
-func (c *ReportController) fetchContracts(ctx context.Context) error {
+// FetchContracts fetches all contracts of ReportController.Employee.
+func (c *ReportController) FetchContracts(ctx context.Context) error {
 	contracts, err := c.OdooClient.FetchAllContractsOfEmployee(ctx, c.Employee.ID)
 	c.Contracts = contracts
 	return err
 }
 
+func (c *ReportController) fetchUser(ctx context.Context) error {
+	user, err := c.OdooClient.FetchUserByID(ctx, c.OdooSession.UID)
+	c.User = user
+	return err
+}
+
 func (c *ReportController) fetchAttendances(ctx context.Context) error {
-	begin, end := c.Input.GetDateRange()
-	// get more entries to cover all timezones, filter out later.
-	begin = begin.AddDate(0, 0, -1)
-	end = end.AddDate(0, 0, 1)
+	begin, end := c.getFetchRange()
 	attendances, err := c.OdooClient.FetchAttendancesBetweenDates(ctx, c.Employee.ID, begin, end)
 	c.Attendances = attendances
 	return err
 }
 
 func (c *ReportController) fetchLeaves(ctx context.Context) error {
-	begin, end := c.Input.GetDateRange()
-	// get more entries to cover all timezones, filter out later.
-	begin = begin.AddDate(0, 0, -1)
-	end = end.AddDate(0, 0, 1)
+	begin, end := c.getFetchRange()
 	leaves, err := c.OdooClient.FetchLeavesBetweenDates(ctx, c.Employee.ID, begin, end)
 	c.Leaves = leaves
 	return err
 }
+
+// getFetchRange returns the date range of the report with an additional day before and after.
+// This gets more entries to cover all timezones, they're filtered out later.
+func (c *ReportController) getFetchRange() (time.Time, time.Time) {
+	var r dateRange = c.Input
+	if c.dateRange != nil {
+		r = c.dateRange
+	}
+	begin, end := r.GetDateRange()
+	return begin.AddDate(0, 0, -1), end.AddDate(0, 0, 1)
+}
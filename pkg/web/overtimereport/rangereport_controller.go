@@ -0,0 +1,95 @@
+package overtimereport
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+	"github.com/vshn/odootools/pkg/web/reportconfig"
+)
+
+type RangeReportController struct {
+	ReportController
+	Range      reportconfig.RangeReportRequest
+	ReportView *rangeReportView
+	Payslips   model.PayslipList
+	Report     timesheet.Report
+}
+
+func NewRangeReportController(ctx controller.BaseController) *RangeReportController {
+	return &RangeReportController{
+		ReportController: ReportController{
+			BaseController: ctx,
+		},
+		ReportView: &rangeReportView{},
+	}
+}
+
+// DisplayRangeReport GET /report/:employee/range?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (c *RangeReportController) DisplayRangeReport() error {
+	root := pipeline.NewPipeline[context.Context]()
+	root.WithSteps(metrics.InstrumentSteps("range report",
+		root.NewStep("parse user input", c.parseInput),
+		root.NewStep("fetch employee", c.FetchEmployeeByID),
+		root.NewStep("fetch payslips", c.fetchPayslips),
+		root.NewStep("fetch user settings", c.fetchUser),
+		root.NewStep("fetch contracts", c.FetchContracts),
+		root.NewStep("fetch attendances", c.fetchAttendances),
+		root.NewStep("fetch leaves", c.fetchLeaves),
+		root.NewStep("calculate range report", c.calculateReport),
+		root.NewStep("render report", c.renderReport),
+	)...)
+	err := root.RunWithContext(c.RequestContext)
+	return err
+}
+
+func (c *RangeReportController) parseInput(_ context.Context) error {
+	input := reportconfig.RangeReportRequest{}
+	err := input.FromRequest(c.Echo)
+	c.Range = input
+	c.Input.EmployeeID = input.EmployeeID
+	c.dateRange = input
+	return err
+}
+
+func (c *RangeReportController) calculateReport(_ context.Context) error {
+	tz := c.getTimeZone()
+	start := time.Date(c.Range.From.Year(), c.Range.From.Month(), c.Range.From.Day(), 0, 0, 0, 0, tz)
+	end := time.Date(c.Range.To.Year(), c.Range.To.Month(), c.Range.To.Day()+1, 0, 0, 0, 0, tz)
+	reporter := timesheet.NewReporter(c.Attendances.AddCurrentTimeAsSignOut(tz), c.Leaves, c.Employee, c.Contracts)
+	report, err := reporter.CalculateReport(start, end)
+	c.Report = report
+	return err
+}
+
+func (c *RangeReportController) renderReport(_ context.Context) error {
+	values := c.ReportView.GetValuesForRangeReport(c.Report, c.Range)
+	return c.Echo.Render(http.StatusOK, rangeReportTemplateName, values)
+}
+
+// getTimeZone returns the zone of the payslip that covers the first day of the range.
+// The user's preference is only considered if the range includes today, like in the monthly report.
+func (c *RangeReportController) getTimeZone() *time.Location {
+	payslip := c.Payslips.FilterInMonth(c.Range.From)
+	if payslip != nil && !payslip.TimeZone.IsEmpty() {
+		return payslip.TimeZone.Location
+	}
+	today := time.Now()
+	if c.User != nil && !today.Before(c.Range.From) && today.Before(c.Range.To.AddDate(0, 0, 1)) {
+		return c.User.TimeZone.LocationOrDefault(timesheet.DefaultTimeZone)
+	}
+	return timesheet.DefaultTimeZone
+}
+
+func (c *RangeReportController) fetchPayslips(ctx context.Context) error {
+	firstMonth := time.Date(c.Range.From.Year(), c.Range.From.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := time.Date(c.Range.To.Year(), c.Range.To.Month(), 1, 0, 0, 0, 0, time.UTC)
+	payslips, err := c.OdooClient.FetchPayslipBetween(ctx, c.Employee.ID, firstMonth, lastMonth.AddDate(0, 1, 1))
+	c.Payslips = payslips
+	return err
+}
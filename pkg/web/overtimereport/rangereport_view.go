@@ -0,0 +1,79 @@
+package overtimereport
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+	"github.com/vshn/odootools/pkg/web/reportconfig"
+)
+
+const rangeReportTemplateName string = "overtimereport-range"
+
+type rangeReportView struct {
+	monthlyReportView
+}
+
+func (v *rangeReportView) GetValuesForRangeReport(report timesheet.Report, input reportconfig.RangeReportRequest) controller.Values {
+	hasInvalidAttendances := ""
+	fixer := timesheet.NewFixer(report)
+	weeks := make([]controller.Values, 0)
+	for _, week := range report.SplitByWeek() {
+		days := make([]controller.Values, 0, len(week.DailySummaries))
+		for _, summary := range week.DailySummaries {
+			if !summary.IsWorkingDay() && summary.CalculateOvertimeSummary().WorkingTime() == 0 {
+				continue
+			}
+			values := v.formatDay(report.Employee.ID, summary, fixer)
+			if values["ValidationError"] != nil {
+				hasInvalidAttendances = "Your timesheet contains errors."
+			}
+			days = append(days, values)
+		}
+		weeks = append(weeks, controller.Values{
+			"Name":    fmt.Sprintf("Week %d, %d", week.Week, week.Year),
+			"Days":    days,
+			"Summary": v.formatRangeSummary(week.Summary),
+		})
+	}
+	// the range is shifted by its length for previous and next links.
+	days := int(input.To.Sub(input.From).Hours()/24) + 1
+	today := time.Now()
+	return controller.Values{
+		"Weeks":   weeks,
+		"Warning": hasInvalidAttendances,
+		"Summary": v.formatRangeSummary(report.Summary),
+		"Nav": controller.Values{
+			"LoggedIn":          true,
+			"ActiveView":        rangeReportTemplateName,
+			"PreviousRangeLink": v.rangeLink(report.Employee.ID, input.From.AddDate(0, 0, -days), input.To.AddDate(0, 0, -days)),
+			"NextRangeLink":     v.rangeLink(report.Employee.ID, input.From.AddDate(0, 0, days), input.To.AddDate(0, 0, days)),
+			"Last4WeeksLink":    v.rangeLink(report.Employee.ID, today.AddDate(0, 0, -27), today),
+		},
+		"FormAction":          fmt.Sprintf("/report/%d/range", report.Employee.ID),
+		"From":                input.From.Format(odoo.DateFormat),
+		"To":                  input.To.Format(odoo.DateFormat),
+		"Username":            report.Employee.Name,
+		"RangeDisplayName":    fmt.Sprintf("%s – %s", input.From.Format(odoo.DateFormat), input.To.Format(odoo.DateFormat)),
+		"TimezoneDisplayName": report.From.Location().String(),
+	}
+}
+
+func (v *rangeReportView) formatRangeSummary(s timesheet.Summary) controller.Values {
+	return controller.Values{
+		"TotalOvertime":     v.FormatDurationInHours(s.TotalOvertime),
+		"TotalLeaves":       fmt.Sprintf("%sd", v.FormatFloat(s.TotalLeave, 1)),
+		"TotalWorked":       v.FormatDurationInHours(s.TotalWorkedTime),
+		"TotalExcused":      v.FormatDurationInHours(s.TotalExcusedTime),
+		"OvertimeClassname": v.OvertimeClassname(s.TotalOvertime),
+	}
+}
+
+// rangeLink returns the link to the range report from the first until the last day (both inclusive).
+func (v *rangeReportView) rangeLink(employeeID int, from, to time.Time) string {
+	query := url.Values{"from": {from.Format(odoo.DateFormat)}, "to": {to.Format(odoo.DateFormat)}}
+	return fmt.Sprintf("/report/%d/range?%s", employeeID, query.Encode())
+}
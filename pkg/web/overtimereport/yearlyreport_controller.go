@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/timesheet"
 	"github.com/vshn/odootools/pkg/web/controller"
@@ -28,15 +29,15 @@ func NewYearlyReportController(controller controller.BaseController) *YearlyRepo
 // DisplayYearlyOvertimeReport GET /report/:id/:year
 func (c *YearlyReportController) DisplayYearlyOvertimeReport() error {
 	root := pipeline.NewPipeline[context.Context]()
-	root.WithSteps(
-		root.NewStep("parse user input", c.parseInput),
-		root.NewStep("fetch employee", c.fetchEmployeeByID),
+	root.WithSteps(metrics.InstrumentSteps("yearly report",
+		root.NewStep("parse user input", c.ParseInput),
+		root.NewStep("fetch employee", c.FetchEmployeeByID),
 		root.NewStep("fetch payslips", c.fetchPayslips),
-		root.NewStep("fetch contracts", c.fetchContracts),
+		root.NewStep("fetch contracts", c.FetchContracts),
 		root.NewStep("fetch attendances", c.fetchAttendances),
 		root.NewStep("fetch leaves", c.fetchLeaves),
 		root.NewStep("calculate monthly report", c.calculateYearlyReport),
-	)
+	)...)
 	err := root.RunWithContext(c.RequestContext)
 	return err
 }
@@ -26,11 +26,12 @@ func (v *yearlyReportView) GetValuesForYearlyReport(report timesheet.YearlyRepor
 		"MonthlyReports": formatted,
 		"Summary":        v.formatYearlySummary(report.Summary),
 		"Nav": controller.Values{
-			"LoggedIn":         true,
-			"ActiveView":       yearlyReportTemplateName,
-			"CurrentYearLink":  fmt.Sprintf(linkFormat, report.Employee.ID, time.Now().Year()),
-			"NextYearLink":     fmt.Sprintf(linkFormat, report.Employee.ID, nextYear),
-			"PreviousYearLink": fmt.Sprintf(linkFormat, report.Employee.ID, prevYear),
+			"LoggedIn":           true,
+			"ActiveView":         yearlyReportTemplateName,
+			"CurrentYearLink":    fmt.Sprintf(linkFormat, report.Employee.ID, time.Now().Year()),
+			"NextYearLink":       fmt.Sprintf(linkFormat, report.Employee.ID, nextYear),
+			"PreviousYearLink":   fmt.Sprintf(linkFormat, report.Employee.ID, prevYear),
+			"VacationReportLink": fmt.Sprintf(linkFormat+"/vacation", report.Employee.ID, report.Year),
 		},
 		"Username": report.Employee.Name,
 	}
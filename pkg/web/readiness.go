@@ -0,0 +1,64 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vshn/odootools/pkg/odoo"
+)
+
+// ReadinessCheck returns an error if the server isn't ready to serve requests.
+type ReadinessCheck func(ctx context.Context) error
+
+type namedReadinessCheck struct {
+	name  string
+	check ReadinessCheck
+}
+
+// AddReadinessCheck adds a check that has to pass for /readyz to report the server as ready.
+func (s *Server) AddReadinessCheck(name string, check ReadinessCheck) {
+	s.readinessChecks = append(s.readinessChecks, namedReadinessCheck{name: name, check: check})
+}
+
+// Readyz GET /readyz
+// It responds with 503 Service Unavailable and the failed checks if any ReadinessCheck fails.
+func (s *Server) Readyz(e echo.Context) error {
+	failures := make([]string, 0)
+	for _, c := range s.readinessChecks {
+		if err := c.check(e.Request().Context()); err != nil {
+			e.Logger().Errorf("readiness check %q failed: %v", c.name, err)
+			failures = append(failures, fmt.Sprintf("%s: %s", c.name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return e.String(http.StatusServiceUnavailable, strings.Join(failures, "\n"))
+	}
+	return e.String(http.StatusOK, "")
+}
+
+// schemaCheckInterval is the duration after which a successful schema check is repeated.
+const schemaCheckInterval = 10 * time.Minute
+
+// NewSchemaCheck returns a ReadinessCheck that verifies the Odoo schema with odoo.VerifySchema.
+// A successful result is reused for some minutes to avoid querying Odoo on every probe, while failures are checked again on the next probe.
+func NewSchemaCheck(querier odoo.QueryExecutor, schemas []odoo.ModelSchema) ReadinessCheck {
+	mu := sync.Mutex{}
+	lastSuccess := time.Time{}
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(lastSuccess) < schemaCheckInterval {
+			return nil
+		}
+		if err := odoo.VerifySchema(ctx, querier, schemas); err != nil {
+			return err
+		}
+		lastSuccess = time.Now()
+		return nil
+	}
+}
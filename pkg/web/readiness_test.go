@@ -0,0 +1,48 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadyz(t *testing.T) {
+	tests := map[string]struct {
+		givenChecks      map[string]ReadinessCheck
+		expectedStatus   int
+		expectedResponse string
+	}{
+		"GivenNoChecks_ThenExpectReady": {
+			expectedStatus: http.StatusOK,
+		},
+		"GivenPassingCheck_ThenExpectReady": {
+			givenChecks: map[string]ReadinessCheck{
+				"odoo schema": func(ctx context.Context) error { return nil },
+			},
+			expectedStatus: http.StatusOK,
+		},
+		"GivenFailingCheck_ThenExpectUnavailable": {
+			givenChecks: map[string]ReadinessCheck{
+				"odoo schema": func(ctx context.Context) error { return errors.New("hr.payslip.x_overtime: missing field") },
+			},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedResponse: "odoo schema: hr.payslip.x_overtime: missing field",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer("")
+			for checkName, check := range tc.givenChecks {
+				server.AddReadinessCheck(checkName, check)
+			}
+			res := httptest.NewRecorder()
+			server.ServeHTTP(res, httptest.NewRequest("GET", "/readyz", nil))
+			assert.Equal(t, tc.expectedStatus, res.Code, "http status")
+			assert.Equal(t, tc.expectedResponse, res.Body.String())
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package web
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/labstack/echo/v4"
+	"github.com/vshn/odootools/pkg/odoo"
 	"github.com/vshn/odootools/pkg/web/employeereport"
 	"github.com/vshn/odootools/pkg/web/overtimereport"
 	"github.com/vshn/odootools/pkg/web/reportconfig"
+	"github.com/vshn/odootools/pkg/web/vacationreport"
 )
 
 // MonthlyOvertimeReport GET /report/:id/:year/:month
@@ -16,6 +21,28 @@ func (s *Server) MonthlyOvertimeReport(e echo.Context) error {
 	return nil
 }
 
+// AttendanceForm GET /report/:employee/:year/:month/:day
+func (s *Server) AttendanceForm(e echo.Context) error {
+	ctrl := overtimereport.NewAttendanceController(*s.newControllerContext(e))
+	if err := ctrl.DisplayAttendanceForm(); err != nil {
+		return s.ShowError(e, err)
+	}
+	return nil
+}
+
+// AttendanceChange POST /report/:employee/:year/:month/:day
+// Previews or saves a change of an attendance.
+// The attendances are always fetched fresh from Odoo, bypassing the cache.
+func (s *Server) AttendanceChange(e echo.Context) error {
+	ctx := s.newControllerContext(e)
+	ctx.RequestContext = odoo.WithBypassCache(ctx.RequestContext)
+	ctrl := overtimereport.NewAttendanceController(*ctx)
+	if err := ctrl.ProcessAttendanceChange(); err != nil {
+		return s.ShowError(e, err)
+	}
+	return nil
+}
+
 // YearlyOvertimeReport GET /report/:id/:year
 func (s *Server) YearlyOvertimeReport(e echo.Context) error {
 	ctrl := overtimereport.NewYearlyReportController(*s.newControllerContext(e))
@@ -25,9 +52,31 @@ func (s *Server) YearlyOvertimeReport(e echo.Context) error {
 	return nil
 }
 
+// RangeOvertimeReport GET /report/:employee/range?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (s *Server) RangeOvertimeReport(e echo.Context) error {
+	ctrl := overtimereport.NewRangeReportController(*s.newControllerContext(e))
+	if err := ctrl.DisplayRangeReport(); err != nil {
+		return s.ShowError(e, err)
+	}
+	return nil
+}
+
+// VacationReport GET /report/:employee/:year/vacation
+func (s *Server) VacationReport(e echo.Context) error {
+	ctrl := vacationreport.NewVacationReportController(*s.newControllerContext(e))
+	if err := ctrl.DisplayVacationReport(); err != nil {
+		return s.ShowError(e, err)
+	}
+	return nil
+}
+
 // RequestReportForm GET /report
 func (s *Server) RequestReportForm(e echo.Context) error {
-	return reportconfig.NewConfigController(s.newControllerContext(e)).ShowConfigurationFormAndWeeklyReport()
+	ctrl := reportconfig.NewConfigController(s.newControllerContext(e))
+	if err := ctrl.ShowConfigurationFormAndWeeklyReport(); err != nil {
+		return s.ShowError(e, err)
+	}
+	return nil
 }
 
 // ProcessReportInput POST /report
@@ -49,9 +98,26 @@ func (s *Server) EmployeeReport(e echo.Context) error {
 	return nil
 }
 
+// EmployeesVacationReport GET /report/employees/:year/vacation
+func (s *Server) EmployeesVacationReport(e echo.Context) error {
+	ctrl := vacationreport.NewEmployeesReportController(s.newControllerContext(e))
+	if err := ctrl.DisplayEmployeesReport(); err != nil {
+		return s.ShowError(e, err)
+	}
+	return nil
+}
+
 // EmployeeReportUpdate POST /report/employee/:employee/:year/:month.
 // Updates the payslip with the overtime value of the given month.
+// The payslip is always fetched fresh from Odoo, bypassing the cache.
 func (s *Server) EmployeeReportUpdate(e echo.Context) error {
-	ctrl := employeereport.NewUpdatePayslipController(s.newControllerContext(e))
-	return ctrl.UpdatePayslipOfEmployee()
+	ctx := s.newControllerContext(e)
+	ctx.RequestContext = odoo.WithBypassCache(ctx.RequestContext)
+	ctrl := employeereport.NewUpdatePayslipController(ctx)
+	err := ctrl.UpdatePayslipOfEmployee()
+	if errors.Is(err, odoo.ErrSessionExpired) {
+		s.clearCookies(e)
+		return e.JSON(http.StatusUnauthorized, employeereport.UpdateResponse{ErrorMessage: err.Error()})
+	}
+	return err
 }
@@ -2,11 +2,14 @@ package reportconfig
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/pkg/odoo"
 	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/timesheet"
@@ -37,7 +40,7 @@ func NewConfigController(ctrl *controller.BaseController) *ConfigController {
 func (c *ConfigController) ShowConfigurationFormAndWeeklyReport() error {
 	c.view.roles = c.SessionData.Roles
 	root := pipeline.NewPipeline[context.Context]()
-	root.WithSteps(
+	root.WithSteps(metrics.InstrumentSteps("report form",
 		root.NewStep("parse user input", c.parseInput),
 		root.WithNestedSteps("weekly report", pipeline.Bool[context.Context](true),
 			root.NewStep("fetch user", c.fetchUser),
@@ -47,7 +50,7 @@ func (c *ConfigController) ShowConfigurationFormAndWeeklyReport() error {
 			root.NewStep("calculate report", c.calculateReport),
 		).WithErrorHandler(c.displayWarning),
 		root.NewStep("render", c.render),
-	)
+	)...)
 	err := root.RunWithContext(c.RequestContext)
 	return err
 }
@@ -97,6 +100,16 @@ func (c *ConfigController) searchEmployee(ctx context.Context) error {
 }
 
 func (c *ConfigController) redirectToReportView(_ context.Context) error {
+	if c.Input.VacationReportEnabled {
+		return c.Echo.Redirect(http.StatusFound, fmt.Sprintf("/report/employees/%d/vacation", c.Input.Year))
+	}
+	if c.Input.RangeReportEnabled {
+		if _, _, err := ParseDateRange(c.Input.From, c.Input.To); err != nil {
+			return err
+		}
+		query := url.Values{"from": {c.Input.From}, "to": {c.Input.To}}
+		return c.Echo.Redirect(http.StatusFound, fmt.Sprintf("/report/%d/range?%s", c.Employee.ID, query.Encode()))
+	}
 	if c.Input.EmployeeReportEnabled {
 		return c.Echo.Redirect(http.StatusFound, fmt.Sprintf("/report/employees/%d/%02d", c.Input.Year, c.Input.Month))
 	}
@@ -154,6 +167,9 @@ func (c *ConfigController) calculateReport(_ context.Context) error {
 }
 
 func (c *ConfigController) displayWarning(_ context.Context, err error) error {
+	if errors.Is(err, odoo.ErrSessionExpired) {
+		return err
+	}
 	if err != nil {
 		c.view.warning = err.Error()
 	}
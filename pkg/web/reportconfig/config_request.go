@@ -1,10 +1,13 @@
 package reportconfig
 
 import (
+	"fmt"
 	"html"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/web/controller"
 )
 
 type BaseReportRequest struct {
@@ -25,7 +28,23 @@ type ReportRequest struct {
 	SearchUser            string `form:"username"`
 	SearchUserEnabled     bool
 	EmployeeReportEnabled bool
+	VacationReportEnabled bool
+	RangeReportEnabled    bool
 	EmployeeID            int `param:"employee"`
+	// From and To are the first and last day of a range report, formatted as odoo.DateFormat.
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+// RangeReportRequest contains the input data for reports of an arbitrary date range.
+type RangeReportRequest struct {
+	EmployeeID int    `param:"employee"`
+	FromDate   string `query:"from"`
+	ToDate     string `query:"to"`
+	// From is the first day (inclusive) of the range in time.UTC at midnight.
+	From time.Time
+	// To is the last day (inclusive) of the range in time.UTC at midnight.
+	To time.Time
 }
 
 // FromRequest parses the properties based on the given request echo.Context.
@@ -37,6 +56,8 @@ func (i *ReportRequest) FromRequest(e echo.Context) error {
 		return err
 	}
 	i.EmployeeReportEnabled = e.FormValue("employeeReport") == "true"
+	i.VacationReportEnabled = e.FormValue("vacationReport") == "true"
+	i.RangeReportEnabled = e.FormValue("rangeReport") == "true"
 	i.SearchUser = html.EscapeString(i.SearchUser)
 	i.SearchUserEnabled = i.SearchUser != ""
 
@@ -103,3 +124,42 @@ func (i BaseReportRequest) GetDateRange() (time.Time, time.Time) {
 
 	return i.GetLastDayFromPreviousMonth(), i.GetFirstDayOfNextMonth()
 }
+
+// FromRequest parses the properties based on the given request echo.Context.
+func (i *RangeReportRequest) FromRequest(e echo.Context) error {
+	if err := e.Bind(i); err != nil {
+		return fmt.Errorf("%w: %v", controller.ErrBadRequest, err)
+	}
+	from, to, err := ParseDateRange(i.FromDate, i.ToDate)
+	i.From = from
+	i.To = to
+	return err
+}
+
+// ParseDateRange parses the first and last day of a range, both formatted as odoo.DateFormat.
+// It returns an error wrapping controller.ErrBadRequest if the days are missing or malformed, if the last day is before the first day or if the range is longer than a year.
+func ParseDateRange(from, to string) (time.Time, time.Time, error) {
+	if from == "" || to == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: the first and last day of the range are required", controller.ErrBadRequest)
+	}
+	first, err := time.Parse(odoo.DateFormat, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: cannot parse first day of the range: %v", controller.ErrBadRequest, err)
+	}
+	last, err := time.Parse(odoo.DateFormat, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: cannot parse last day of the range: %v", controller.ErrBadRequest, err)
+	}
+	if last.Before(first) {
+		return first, last, fmt.Errorf("%w: the last day of the range (%s) cannot be before the first day (%s)", controller.ErrBadRequest, to, from)
+	}
+	if !last.Before(first.AddDate(1, 0, 0)) {
+		return first, last, fmt.Errorf("%w: the range from %s to %s cannot be longer than a year", controller.ErrBadRequest, from, to)
+	}
+	return first, last, nil
+}
+
+// GetDateRange returns the day before RangeReportRequest.From and the day after RangeReportRequest.To, like BaseReportRequest.GetDateRange.
+func (i RangeReportRequest) GetDateRange() (time.Time, time.Time) {
+	return i.From.AddDate(0, 0, -1), i.To.AddDate(0, 0, 1)
+}
@@ -0,0 +1,126 @@
+package reportconfig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := map[string]struct {
+		givenFrom     string
+		givenTo       string
+		expectedFrom  time.Time
+		expectedTo    time.Time
+		expectedError string
+	}{
+		"GivenValidRange_ThenReturnDays": {
+			givenFrom:    "2022-02-28",
+			givenTo:      "2022-03-06",
+			expectedFrom: time.Date(2022, time.February, 28, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC),
+		},
+		"GivenSingleDay_ThenReturnSameDay": {
+			givenFrom:    "2022-03-01",
+			givenTo:      "2022-03-01",
+			expectedFrom: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"GivenWholeYear_ThenReturnDays": {
+			givenFrom:    "2022-01-01",
+			givenTo:      "2022-12-31",
+			expectedFrom: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		"GivenEmptyFrom_ThenReturnError": {
+			givenTo:       "2022-03-06",
+			expectedError: "invalid input: the first and last day of the range are required",
+		},
+		"GivenMalformedTo_ThenReturnError": {
+			givenFrom:     "2022-02-28",
+			givenTo:       "06.03.2022",
+			expectedError: "invalid input: cannot parse last day of the range",
+		},
+		"GivenReversedRange_ThenReturnError": {
+			givenFrom:     "2022-03-06",
+			givenTo:       "2022-02-28",
+			expectedError: "invalid input: the last day of the range (2022-02-28) cannot be before the first day (2022-03-06)",
+		},
+		"GivenRangeLongerThanYear_ThenReturnError": {
+			givenFrom:     "2022-01-01",
+			givenTo:       "2023-01-01",
+			expectedError: "invalid input: the range from 2022-01-01 to 2023-01-01 cannot be longer than a year",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, to, err := ParseDateRange(tc.givenFrom, tc.givenTo)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.ErrorIs(t, err, controller.ErrBadRequest)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFrom, from, "from")
+			assert.Equal(t, tc.expectedTo, to, "to")
+		})
+	}
+}
+
+func TestRangeReportRequest_FromRequest(t *testing.T) {
+	tests := map[string]struct {
+		givenQuery        string
+		expectedRequest   RangeReportRequest
+		expectedError     string
+		expectedDateRange [2]time.Time
+	}{
+		"GivenValidRange_ThenParseDays": {
+			givenQuery: "from=2022-02-28&to=2022-03-06",
+			expectedRequest: RangeReportRequest{
+				EmployeeID: 2,
+				FromDate:   "2022-02-28",
+				ToDate:     "2022-03-06",
+				From:       time.Date(2022, time.February, 28, 0, 0, 0, 0, time.UTC),
+				To:         time.Date(2022, time.March, 6, 0, 0, 0, 0, time.UTC),
+			},
+			expectedDateRange: [2]time.Time{
+				time.Date(2022, time.February, 27, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, time.March, 7, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"GivenMissingTo_ThenReturnError": {
+			givenQuery:    "from=2022-02-28",
+			expectedError: "invalid input: the first and last day of the range are required",
+		},
+		"GivenReversedRange_ThenReturnError": {
+			givenQuery:    "from=2022-03-06&to=2022-02-28",
+			expectedError: "invalid input: the last day of the range (2022-02-28) cannot be before the first day (2022-03-06)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/report/2/range?"+tc.givenQuery, nil)
+			e := echo.New().NewContext(req, httptest.NewRecorder())
+			e.SetParamNames("employee")
+			e.SetParamValues("2")
+
+			input := RangeReportRequest{}
+			err := input.FromRequest(e)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Equal(t, http.StatusBadRequest, controller.HTTPStatusOf(err, http.StatusInternalServerError))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRequest, input)
+			begin, end := input.GetDateRange()
+			assert.Equal(t, tc.expectedDateRange, [2]time.Time{begin, end})
+		})
+	}
+}
@@ -17,7 +17,7 @@ type ConfigView struct {
 func (v *ConfigView) GetConfigurationValues(report timesheet.Report) controller.Values {
 	formatted := make([]controller.Values, 0)
 	for _, summary := range report.DailySummaries {
-		if summary.IsWeekend() && summary.CalculateOvertimeSummary().WorkingTime() == 0 {
+		if !summary.IsWorkingDay() && summary.CalculateOvertimeSummary().WorkingTime() == 0 {
 			continue
 		}
 		formatted = append(formatted, v.FormatDailySummary(summary))
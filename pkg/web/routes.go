@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/templates"
 )
 
@@ -12,6 +13,8 @@ func (s *Server) setupRoutes(middleware ...echo.MiddlewareFunc) {
 	e := s.Echo
 	// System setupRoutes
 	e.GET("/healthz", Healthz)
+	e.GET("/readyz", s.Readyz)
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
 
 	// Application routes
 	e.GET("/", s.RedirectTo("/report"))
@@ -20,10 +23,15 @@ func (s *Server) setupRoutes(middleware ...echo.MiddlewareFunc) {
 	report := e.Group("/report", middleware...)
 	report.GET("", s.RequestReportForm)
 	report.POST("", s.ProcessReportInput)
+	report.GET("/employees/:year/vacation", s.EmployeesVacationReport)
 	report.GET("/employees/:year/:month", s.EmployeeReport)
 	report.POST("/employee/:employee/:year/:month", s.EmployeeReportUpdate)
+	report.GET("/:employee/range", s.RangeOvertimeReport)
 	report.GET("/:employee/:year", s.YearlyOvertimeReport)
+	report.GET("/:employee/:year/vacation", s.VacationReport)
 	report.GET("/:employee/:year/:month", s.MonthlyOvertimeReport)
+	report.GET("/:employee/:year/:month/:day", s.AttendanceForm)
+	report.POST("/:employee/:year/:month/:day", s.AttendanceChange)
 
 	e.GET("/help", s.helpPage, middleware...)
 
@@ -0,0 +1,27 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	server := newTestServer("")
+	server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthz", nil))
+	server.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/does/not/exist", nil))
+
+	// no session cookie required
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, http.StatusOK, res.Code, "http status")
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `odootools_http_request_duration_seconds_count{code="200",method="GET",route="/healthz"}`)
+	assert.NotContains(t, string(body), `route="/does/not/exist"`, "unknown paths shouldn't create new series")
+	assert.Contains(t, string(body), "go_goroutines")
+}
@@ -2,9 +2,11 @@ package web
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/funcr"
@@ -12,6 +14,7 @@ import (
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/pkg/odoo"
 	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/web/controller"
@@ -23,10 +26,15 @@ type Server struct {
 	cookieStore *sessions.CookieStore
 	dbName      string
 	versionInfo VersionInfo
+	queryCache  *odoo.QueryCache
+	// odooVersion is a pointer, so that UseOdooVersion also affects the handlers with value receivers, which are bound to copies of the Server.
+	odooVersion *odoo.ServerVersion
+
+	readinessChecks []namedReadinessCheck
 }
 
 func NewServer(
-	odoo *odoo.Client,
+	odooClient *odoo.Client,
 	secretKey string,
 	dbName string,
 	versionInfo VersionInfo,
@@ -37,14 +45,17 @@ func NewServer(
 	}
 
 	s := Server{
-		odooClient:  odoo,
+		odooClient:  odooClient,
 		dbName:      dbName,
 		Echo:        echo.New(),
 		cookieStore: sessions.NewCookieStore(key, key),
 		versionInfo: versionInfo,
+		queryCache:  odoo.NewQueryCache(odoo.DefaultCacheOptions()),
+		odooVersion: &odoo.ServerVersion{},
 	}
 	e := s.Echo
 	e.Pre(middleware.RemoveTrailingSlash())
+	e.Use(metrics.EchoMiddleware())
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Skipper:          s.skipAccessLogs,
 		Format:           middleware.DefaultLoggerConfig.Format,
@@ -55,11 +66,17 @@ func NewServer(
 	}))
 	authMiddleware := middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
 		KeyLookup: "cookie:" + SessionCookieID,
-		Validator: func(s string, context echo.Context) (bool, error) {
-			// 's' contains already the encrypted cookie value, which means we likely have a valid odoo session
+		Validator: func(_ string, context echo.Context) (bool, error) {
+			// the cookie value is encrypted, if we can restore the Odoo session from it we likely have a valid odoo session.
+			if s.GetOdooSession(context) == nil {
+				return false, odoo.ErrSessionExpired
+			}
 			return true, nil
 		},
 		ErrorHandler: func(err error, context echo.Context) error {
+			if errors.Is(err, odoo.ErrSessionExpired) {
+				return s.redirectToLogin(context)
+			}
 			return context.Redirect(http.StatusTemporaryRedirect, "/login")
 		},
 		Skipper: s.unprotectedRoutes(),
@@ -73,6 +90,16 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.Echo.ServeHTTP(w, r)
 }
 
+// UseOdooVersion sets the version of the Odoo server, which determines the Odoo models that are queried.
+// Odoo 8 is assumed by default.
+func (s *Server) UseOdooVersion(version odoo.ServerVersion) {
+	*s.odooVersion = version
+}
+
+func (s *Server) newOdoo(querier odoo.QueryExecutor) *model.Odoo {
+	return model.NewOdooForVersion(querier, *s.odooVersion)
+}
+
 func (s *Server) newControllerContext(e echo.Context) *controller.BaseController {
 	sess := s.GetOdooSession(e)
 	data := s.GetSessionData(e)
@@ -80,11 +107,35 @@ func (s *Server) newControllerContext(e echo.Context) *controller.BaseController
 		// TODO: Integrate with echo logger?
 		fmt.Println(obj)
 	}, funcr.Options{Verbosity: 2}))
-	return &controller.BaseController{Echo: e, OdooClient: model.NewOdoo(sess), OdooSession: sess, SessionData: data, RequestContext: logCtx}
+	if bypass, _ := strconv.ParseBool(e.QueryParam(controller.BypassCacheParam)); bypass {
+		logCtx = odoo.WithBypassCache(logCtx)
+	}
+	var querier odoo.QueryExecutor = sess
+	if sess != nil {
+		querier = s.queryCache.Wrap(sess, strconv.Itoa(sess.UID))
+	}
+	return &controller.BaseController{Echo: e, OdooClient: s.newOdoo(querier), OdooSession: sess, SessionData: data, RequestContext: logCtx}
 }
 
+// ShowError renders the error page with the status code given by controller.HTTPStatusOf.
+// If the Odoo session has expired, the user is redirected to the login page instead.
 func (s *Server) ShowError(e echo.Context, err error) error {
-	return e.Render(http.StatusInternalServerError, "error", controller.AsError(err))
+	if errors.Is(err, odoo.ErrSessionExpired) {
+		return s.redirectToLogin(e)
+	}
+	status := controller.HTTPStatusOf(err, http.StatusInternalServerError)
+	values := controller.AsError(err)
+	switch status {
+	case http.StatusBadRequest:
+		values["Title"] = "Invalid input"
+	case http.StatusForbidden:
+		values["Title"] = "Access denied"
+	case http.StatusUnprocessableEntity:
+		values["Title"] = "Odoo rejected the request"
+	case http.StatusBadGateway:
+		values["Title"] = "Odoo failed to process the request"
+	}
+	return e.Render(status, "error", values)
 }
 
 var publicRoutes = []string{
@@ -92,6 +143,8 @@ var publicRoutes = []string{
 	"/robots.txt",
 	"/static/*",
 	"/healthz",
+	"/readyz",
+	"/metrics",
 }
 
 func (s *Server) skipAccessLogs(e echo.Context) bool {
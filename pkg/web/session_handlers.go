@@ -4,22 +4,26 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	pipeline "github.com/ccremer/go-command-pipeline"
 	"github.com/labstack/echo/v4"
+	"github.com/vshn/odootools/pkg/metrics"
 	"github.com/vshn/odootools/pkg/odoo"
-	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/web/controller"
 )
 
 const (
 	// SessionCookieID is the session cookie identifier.
 	SessionCookieID = "odootools"
+	// ReturnToParam is the query parameter that holds the URL to redirect to after a successful login.
+	ReturnToParam = "returnTo"
 )
 
 // LoginForm GET /login
 func (s Server) LoginForm(e echo.Context) error {
-	return e.Render(http.StatusOK, "login", nil)
+	return e.Render(http.StatusOK, "login", controller.Values{"ReturnTo": sanitizeReturnTo(e.QueryParam(ReturnToParam))})
 }
 
 // Login POST /login
@@ -30,18 +34,24 @@ func (s Server) Login(e echo.Context) error {
 		Password:     e.FormValue("password"),
 	})
 	if errors.Is(err, odoo.ErrInvalidCredentials) {
-		return e.Render(http.StatusOK, "login", controller.Values{"Error": "Invalid login or password"})
+		metrics.ObserveLogin(metrics.LoginInvalidCredentials)
+		return e.Render(http.StatusOK, "login", controller.Values{
+			"Error":    "Invalid login or password",
+			"ReturnTo": sanitizeReturnTo(e.FormValue(ReturnToParam)),
+		})
 	}
 	if err != nil {
+		metrics.ObserveLogin(metrics.LoginError)
 		e.Logger().Error(err)
 		return e.Render(http.StatusBadGateway, "error", controller.AsError(errors.New("got an error from Odoo, check logs")))
 	}
 
+	metrics.ObserveLogin(metrics.LoginSuccess)
 	return s.runPostLogin(e, odooSession)
 }
 
 func (s Server) runPostLogin(e echo.Context, odooSession *odoo.Session) error {
-	o := model.NewOdoo(odooSession)
+	o := s.newOdoo(odooSession)
 	sessionData := controller.SessionData{}
 	p := pipeline.NewPipeline[context.Context]()
 	p.WithSteps(
@@ -52,12 +62,8 @@ func (s Server) runPostLogin(e echo.Context, odooSession *odoo.Session) error {
 		}),
 		p.NewStep("fetch manager group", func(ctx context.Context) error {
 			group, err := o.FetchGroupByName(ctx, "Human Resources", "Manager")
-			if group != nil {
-				for _, userID := range group.UserIDs {
-					if odooSession.UID == userID {
-						sessionData.Roles = []string{controller.HRManagerRoleKey}
-					}
-				}
+			if group != nil && group.UserIDs.Contains(odooSession.UID) {
+				sessionData.Roles = []string{controller.HRManagerRoleKey}
 			}
 			return err
 		}),
@@ -68,6 +74,9 @@ func (s Server) runPostLogin(e echo.Context, odooSession *odoo.Session) error {
 			if err := s.SaveSessionData(e, sessionData); err != nil {
 				return err
 			}
+			if returnTo := sanitizeReturnTo(e.FormValue(ReturnToParam)); returnTo != "" {
+				return e.Redirect(http.StatusFound, returnTo)
+			}
 			return e.Redirect(http.StatusFound, "/report")
 		}).WithErrorHandler(func(ctx context.Context, err error) error {
 			if err != nil {
@@ -81,7 +90,35 @@ func (s Server) runPostLogin(e echo.Context, odooSession *odoo.Session) error {
 
 // Logout GET /logout
 func (s Server) Logout(e echo.Context) error {
+	s.clearCookies(e)
+	return e.Redirect(http.StatusTemporaryRedirect, "/login")
+}
+
+// redirectToLogin clears the session cookies and redirects to the login page.
+// For GET requests, the requested URL is passed along so that the user lands on the same page after logging in again.
+func (s Server) redirectToLogin(e echo.Context) error {
+	s.clearCookies(e)
+	if e.Request().Method != http.MethodGet {
+		return e.Redirect(http.StatusFound, "/login")
+	}
+	query := url.Values{ReturnToParam: []string{e.Request().URL.RequestURI()}}
+	return e.Redirect(http.StatusFound, "/login?"+query.Encode())
+}
+
+func (s Server) clearCookies(e echo.Context) {
 	e.SetCookie(&http.Cookie{Name: SessionCookieID, MaxAge: -1})
 	e.SetCookie(&http.Cookie{Name: DataCookieID, MaxAge: -1})
-	return e.Redirect(http.StatusTemporaryRedirect, "/login")
+}
+
+// sanitizeReturnTo returns the given URL if it's a local path.
+// An empty string is returned for anything else, to avoid redirecting to foreign hosts.
+func sanitizeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") || strings.HasPrefix(returnTo, "/\\") {
+		return ""
+	}
+	parsed, err := url.Parse(returnTo)
+	if err != nil || parsed.IsAbs() || parsed.Host != "" {
+		return ""
+	}
+	return returnTo
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
 )
 
 func TestRenderLoginForm(t *testing.T) {
@@ -253,3 +254,114 @@ func TestLogout(t *testing.T) {
 	assert.Equal(t, SessionCookieID, c.Name, "cookie name")
 	assert.Equal(t, -1, c.MaxAge, "cookie age reset")
 }
+
+func TestLoginSuccess_WithReturnTo(t *testing.T) {
+	numRequests := 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		switch numRequests {
+		case 1:
+			respondLogin(t, w, r)
+		case 2:
+			respondEmployeeSearch(t, w, r)
+		case 3:
+			respondGroupMembershipSearch(t, w, r)
+		default:
+			t.Fail()
+		}
+	}))
+
+	form := url.Values{}
+	form.Set("login", "username")
+	form.Set("password", "password")
+	form.Set(ReturnToParam, "/report/2/2022/03")
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	res := httptest.NewRecorder()
+	newTestServer(odooMock.URL).ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusFound, res.Code, "http status")
+	assert.Equal(t, "/report/2/2022/03", res.Header().Get("Location"), "location header")
+}
+
+func TestSessionExpired(t *testing.T) {
+	numRequests := 0
+	odooMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		w.Header().Set("content-type", "application/json")
+		_, err := w.Write([]byte(`{
+			"jsonrpc": "2.0",
+			"id": "xxx",
+			"error": {
+				"code": 100,
+				"message": "Odoo Session Expired",
+				"data": {
+					"debug": "Traceback xxx",
+					"message": "Session expired",
+					"name": "openerp.http.SessionExpiredException",
+					"arguments": ["Session expired"]
+				}
+			}
+		}`))
+		assert.NoError(t, err)
+	}))
+
+	s := newTestServer(odooMock.URL)
+	cookieRecorder := httptest.NewRecorder()
+	err := s.SaveOdooSession(s.Echo.NewContext(httptest.NewRequest("GET", "/", nil), cookieRecorder), &odoo.Session{SessionID: "sid", UID: 1})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/report/employees/2022/03", nil)
+	for _, c := range cookieRecorder.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	res := httptest.NewRecorder()
+	s.ServeHTTP(res, req)
+
+	assert.Equal(t, 1, numRequests, "number of requests")
+	assert.Equal(t, http.StatusFound, res.Code, "http status code")
+	assert.Equal(t, "/login?returnTo=%2Freport%2Femployees%2F2022%2F03", res.Header().Get("Location"), "location header")
+	require.Len(t, res.Result().Cookies(), 2, "number of cookies")
+	for _, c := range res.Result().Cookies() {
+		assert.Equal(t, -1, c.MaxAge, "cookie age reset")
+	}
+}
+
+func TestSessionExpired_WhenCookieInvalid(t *testing.T) {
+	req := httptest.NewRequest("GET", "/report/2/2022/03", nil)
+	req.AddCookie(&http.Cookie{Name: SessionCookieID, Value: "something"})
+	res := httptest.NewRecorder()
+	newTestServer("").ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusFound, res.Code, "http status code")
+	assert.Equal(t, "/login?returnTo=%2Freport%2F2%2F2022%2F03", res.Header().Get("Location"), "location header")
+}
+
+func Test_sanitizeReturnTo(t *testing.T) {
+	tests := map[string]struct {
+		givenURL    string
+		expectedURL string
+	}{
+		"GivenEmptyURL_ThenExpectEmpty": {
+			givenURL: "", expectedURL: "",
+		},
+		"GivenLocalPath_ThenExpectSamePath": {
+			givenURL: "/report/2/2022/03", expectedURL: "/report/2/2022/03",
+		},
+		"GivenAbsoluteURL_ThenExpectEmpty": {
+			givenURL: "https://example.com/report", expectedURL: "",
+		},
+		"GivenProtocolRelativeURL_ThenExpectEmpty": {
+			givenURL: "//example.com/report", expectedURL: "",
+		},
+		"GivenBackslashURL_ThenExpectEmpty": {
+			givenURL: "/\\example.com/report", expectedURL: "",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedURL, sanitizeReturnTo(tc.givenURL))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package vacationreport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/hashicorp/go-multierror"
+	"github.com/vshn/odootools/pkg/metrics"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/web/controller"
+	"github.com/vshn/odootools/pkg/web/reportconfig"
+)
+
+type EmployeesReportController struct {
+	controller.BaseController
+	Input     reportconfig.ReportRequest
+	employees odoo.List[model.Employee]
+	reports   []*ReportController
+	view      *employeesReportView
+}
+
+func NewEmployeesReportController(ctx *controller.BaseController) *EmployeesReportController {
+	return &EmployeesReportController{
+		BaseController: *ctx,
+		view:           &employeesReportView{},
+	}
+}
+
+// DisplayEmployeesReport GET /report/employees/:year/vacation
+func (c *EmployeesReportController) DisplayEmployeesReport() error {
+	root := pipeline.NewPipeline[context.Context]()
+	root.WithOptions(pipeline.Options{DisableErrorWrapping: true}).
+		WithSteps(metrics.InstrumentSteps("employees vacation report",
+			root.NewStep("parse user input", c.parseInput),
+			root.NewStep("fetch employees", c.fetchEmployees),
+			pipeline.NewWorkerPoolStep("generate reports for each employee", 4, c.createPipelinesForEachEmployee, c.collectReports),
+			root.NewStep("render report", c.renderReport),
+		)...)
+	err := root.RunWithContext(c.RequestContext)
+	return err
+}
+
+func (c *EmployeesReportController) createPipelinesForEachEmployee(ctx context.Context, pipelines chan *pipeline.Pipeline[context.Context]) {
+	defer close(pipelines)
+	c.reports = make([]*ReportController, c.employees.Len())
+	for i, employee := range c.employees.Items {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			ctrl := NewVacationReportController(c.BaseController)
+			ctrl.Employee = employee
+			ctrl.Input.Year = c.Input.Year
+			c.reports[i] = ctrl
+			p := pipeline.NewPipeline[context.Context]()
+			p.AddStep(p.WithNestedSteps(fmt.Sprintf("vacation report for %q", employee.Name), nil, metrics.InstrumentSteps("employees vacation report per employee",
+				p.NewStep("fetch data", ctrl.FetchReportData),
+				p.NewStep("calculate vacation report", ctrl.CalculateVacationReport),
+			)...))
+			pipelines <- p
+		}
+	}
+}
+
+func (c *EmployeesReportController) collectReports(_ context.Context, results map[uint64]error) error {
+	var combined error
+	for _, err := range results {
+		if err != nil {
+			combined = multierror.Append(combined, err)
+		}
+	}
+	return combined
+}
+
+func (c *EmployeesReportController) parseInput(_ context.Context) error {
+	input := reportconfig.ReportRequest{}
+	err := input.FromRequest(c.Echo)
+	c.Input = input
+	return err
+}
+
+func (c *EmployeesReportController) fetchEmployees(ctx context.Context) error {
+	list, err := c.OdooClient.FetchAllEmployees(ctx)
+	c.employees = list
+	return err
+}
+
+func (c *EmployeesReportController) renderReport(_ context.Context) error {
+	c.view.year = c.Input.Year
+	return c.Echo.Render(http.StatusOK, employeesReportTemplateName, c.view.GetValuesForReports(c.reports))
+}
@@ -0,0 +1,45 @@
+package vacationreport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+const employeesReportTemplateName = "vacationreport-employees"
+
+type employeesReportView struct {
+	reportView
+	year int
+}
+
+func (v *employeesReportView) GetValuesForReports(reports []*ReportController) controller.Values {
+	reportValues := make([]controller.Values, len(reports))
+	for i, ctrl := range reports {
+		report := ctrl.Report
+		values := v.formatBalance(report.Summary)
+		values["Name"] = ctrl.Employee.Name
+		values["ReportDirectLink"] = fmt.Sprintf("/report/%d/%d/vacation", ctrl.Employee.ID, v.year)
+		values["Workload"] = v.FormatFloat(report.FTERatio*100, 0)
+		leaveTypes := make([]string, len(report.Balances))
+		for j, balance := range report.Balances {
+			leaveTypes[j] = balance.LeaveType
+		}
+		values["LeaveTypes"] = leaveTypes
+		reportValues[i] = values
+	}
+	linkFormat := "/report/employees/%d/vacation"
+	return controller.Values{
+		"Year":    v.year,
+		"Reports": reportValues,
+		"Nav": controller.Values{
+			"LoggedIn":         true,
+			"ActiveView":       employeesReportTemplateName,
+			"CurrentYearLink":  fmt.Sprintf(linkFormat, time.Now().Year()),
+			"NextYearLink":     fmt.Sprintf(linkFormat, v.year+1),
+			"PreviousYearLink": fmt.Sprintf(linkFormat, v.year-1),
+			"RefreshLink":      fmt.Sprintf(linkFormat+"?%s=true", v.year, controller.BypassCacheParam),
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package vacationreport
+
+import (
+	"context"
+	"net/http"
+
+	pipeline "github.com/ccremer/go-command-pipeline"
+	"github.com/vshn/odootools/pkg/metrics"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+	"github.com/vshn/odootools/pkg/web/overtimereport"
+)
+
+// ReportController shows the vacation balance of an employee.
+// It reuses the input parsing and fetching of employee and contracts of the overtime report.
+type ReportController struct {
+	overtimereport.ReportController
+	Allocations odoo.List[model.Allocation]
+	Report      timesheet.VacationReport
+	view        *reportView
+}
+
+func NewVacationReportController(ctx controller.BaseController) *ReportController {
+	return &ReportController{
+		ReportController: overtimereport.ReportController{BaseController: ctx},
+		view:             &reportView{},
+	}
+}
+
+// DisplayVacationReport GET /report/:employee/:year/vacation
+func (c *ReportController) DisplayVacationReport() error {
+	root := pipeline.NewPipeline[context.Context]()
+	root.WithSteps(metrics.InstrumentSteps("vacation report",
+		root.NewStep("parse user input", c.ParseInput),
+		root.NewStep("fetch employee", c.FetchEmployeeByID),
+		root.NewStep("fetch data", c.FetchReportData),
+		root.NewStep("calculate vacation report", c.CalculateVacationReport),
+		root.NewStep("render report", c.renderReport),
+	)...)
+	err := root.RunWithContext(c.RequestContext)
+	return err
+}
+
+// FetchReportData fetches the contracts, allocations and leaves of ReportController.Employee.
+func (c *ReportController) FetchReportData(ctx context.Context) error {
+	root := pipeline.NewPipeline[context.Context]()
+	root.WithSteps(metrics.InstrumentSteps("vacation report data",
+		root.NewStep("fetch contracts", c.FetchContracts),
+		root.NewStep("fetch allocations", c.fetchAllocations),
+		root.NewStep("fetch leaves", c.fetchLeaves),
+	)...)
+	err := root.RunWithContext(ctx)
+	return err
+}
+
+func (c *ReportController) CalculateVacationReport(_ context.Context) error {
+	c.Report = timesheet.NewVacationReporter(c.Allocations, c.Leaves, c.Employee, c.Contracts).
+		SetYear(c.Input.Year).
+		CalculateVacationReport()
+	return nil
+}
+
+func (c *ReportController) fetchAllocations(ctx context.Context) error {
+	allocations, err := c.OdooClient.FetchAllocations(ctx, c.Employee.ID)
+	c.Allocations = allocations
+	return err
+}
+
+func (c *ReportController) fetchLeaves(ctx context.Context) error {
+	leaveTypes := timesheet.LeaveTypesOfYear(c.Allocations, c.Input.Year)
+	typeIDs := make([]int, len(leaveTypes))
+	for i, leaveType := range leaveTypes {
+		typeIDs[i] = leaveType.ID
+	}
+	leaves, err := c.OdooClient.FetchLeavesOfTypes(ctx, c.Employee.ID, typeIDs)
+	c.Leaves = leaves
+	return err
+}
+
+func (c *ReportController) renderReport(_ context.Context) error {
+	return c.Echo.Render(http.StatusOK, vacationReportTemplateName, c.view.GetValuesForVacationReport(c.Report))
+}
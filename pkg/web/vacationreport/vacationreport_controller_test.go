@@ -0,0 +1,62 @@
+package vacationreport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/odoo/odootest"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+func TestReportController_FetchReportData(t *testing.T) {
+	server := odootest.NewServer(odootest.DefaultFixtures())
+	defer server.Close()
+	client, err := odoo.NewClient(server.URL, odoo.ClientOptions{})
+	require.NoError(t, err)
+	session, err := client.Login(context.Background(), odoo.LoginOptions{DatabaseName: "TestDB", Username: "jane", Password: "secret"})
+	require.NoError(t, err)
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	require.NoError(t, err)
+	defaultTZ := timesheet.DefaultTimeZone
+	timesheet.DefaultTimeZone = zurich
+	t.Cleanup(func() { timesheet.DefaultTimeZone = defaultTZ })
+
+	tests := map[string]struct {
+		givenYear      int
+		expectedLeaves int
+		expectedTaken  float64
+	}{
+		"GivenYearWithAllocation_ThenExpectLeavesOfAllocatedTypes": {
+			givenYear:      2022,
+			expectedLeaves: 2,
+			expectedTaken:  1,
+		},
+		"GivenYearWithoutAllocation_ThenExpectNoLeaves": {
+			givenYear:      2021,
+			expectedLeaves: 0,
+			expectedTaken:  0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewVacationReportController(controller.BaseController{OdooClient: model.NewOdoo(session)})
+			c.Employee = model.Employee{ID: 2, Name: "Jane Doe"}
+			c.Input.Year = tc.givenYear
+
+			require.NoError(t, c.FetchReportData(context.Background()))
+			assert.Len(t, c.Contracts.Items, 1)
+			assert.Len(t, c.Allocations.Items, 1)
+			assert.Len(t, c.Leaves.Items, tc.expectedLeaves)
+
+			require.NoError(t, c.CalculateVacationReport(context.Background()))
+			assert.Equal(t, tc.givenYear, c.Report.Year)
+			assert.Equal(t, tc.expectedTaken, c.Report.Summary.Taken)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package vacationreport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+const vacationReportTemplateName = "vacationreport"
+
+type reportView struct {
+	controller.BaseView
+}
+
+func (v *reportView) GetValuesForVacationReport(report timesheet.VacationReport) controller.Values {
+	balances := make([]controller.Values, len(report.Balances))
+	for i, balance := range report.Balances {
+		balances[i] = v.formatBalance(balance)
+	}
+	linkFormat := "/report/%d/%d/vacation"
+	return controller.Values{
+		"Username": report.Employee.Name,
+		"Year":     report.Year,
+		"Workload": v.FormatFloat(report.FTERatio*100, 0),
+		"Balances": balances,
+		"Summary":  v.formatBalance(report.Summary),
+		"Nav": controller.Values{
+			"LoggedIn":         true,
+			"ActiveView":       vacationReportTemplateName,
+			"CurrentYearLink":  fmt.Sprintf(linkFormat, report.Employee.ID, time.Now().Year()),
+			"NextYearLink":     fmt.Sprintf(linkFormat, report.Employee.ID, report.Year+1),
+			"PreviousYearLink": fmt.Sprintf(linkFormat, report.Employee.ID, report.Year-1),
+			"YearlyReportLink": fmt.Sprintf("/report/%d/%d", report.Employee.ID, report.Year),
+		},
+	}
+}
+
+func (v *reportView) formatBalance(balance timesheet.VacationBalance) controller.Values {
+	return controller.Values{
+		"LeaveType":          balance.LeaveType,
+		"Allocated":          v.FormatFloat(balance.Allocated, 1),
+		"Taken":              v.FormatFloat(balance.Taken, 1),
+		"Pending":            v.FormatFloat(balance.Pending, 1),
+		"Remaining":          v.FormatFloat(balance.Remaining, 1),
+		"RemainingClassname": v.remainingClassname(balance),
+	}
+}
+
+// remainingClassname returns "Undertime" if more days have been taken than allocated, so that the balance is highlighted like a negative overtime balance.
+func (v *reportView) remainingClassname(balance timesheet.VacationBalance) string {
+	if balance.Remaining < 0 {
+		return "Undertime"
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package vacationreport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/model"
+	"github.com/vshn/odootools/pkg/timesheet"
+	"github.com/vshn/odootools/pkg/web/controller"
+)
+
+func TestReportView_GetValuesForVacationReport(t *testing.T) {
+	balance := timesheet.VacationBalance{LeaveType: "Legal Leaves 2022", Allocated: 12.5, Taken: 1, Pending: 2, Remaining: 11.5}
+	report := timesheet.VacationReport{
+		Employee: model.Employee{ID: 2, Name: "Jane Doe"},
+		Year:     2022,
+		FTERatio: 0.5,
+		Balances: []timesheet.VacationBalance{balance},
+		Summary:  balance,
+	}
+
+	v := reportView{}
+	result := v.GetValuesForVacationReport(report)
+	assert.Equal(t, "Jane Doe", result["Username"])
+	assert.Equal(t, 2022, result["Year"])
+	assert.Equal(t, "50", result["Workload"])
+	require.Len(t, result["Balances"], 1)
+	assert.Equal(t, controller.Values{
+		"LeaveType":          "Legal Leaves 2022",
+		"Allocated":          "12.5",
+		"Taken":              "1.0",
+		"Pending":            "2.0",
+		"Remaining":          "11.5",
+		"RemainingClassname": "",
+	}, result["Summary"])
+	nav := result["Nav"].(controller.Values)
+	assert.Equal(t, "/report/2/2023/vacation", nav["NextYearLink"])
+	assert.Equal(t, "/report/2/2021/vacation", nav["PreviousYearLink"])
+	assert.Equal(t, "/report/2/2022", nav["YearlyReportLink"])
+}
+
+func TestReportView_remainingClassname(t *testing.T) {
+	tests := map[string]struct {
+		givenRemaining    float64
+		expectedClassname string
+	}{
+		"GivenPositiveRemaining_ThenExpectEmpty": {
+			givenRemaining:    2.5,
+			expectedClassname: "",
+		},
+		"GivenZeroRemaining_ThenExpectEmpty": {
+			givenRemaining:    0,
+			expectedClassname: "",
+		},
+		"GivenNegativeRemaining_ThenExpectUndertime": {
+			givenRemaining:    -1,
+			expectedClassname: "Undertime",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := reportView{}
+			result := v.remainingClassname(timesheet.VacationBalance{Remaining: tc.givenRemaining})
+			assert.Equal(t, tc.expectedClassname, result)
+		})
+	}
+}
@@ -11,12 +11,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/timesheet"
 	"github.com/vshn/odootools/pkg/web"
 )
 
 func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UnixNano())
 	log.SetOutput(io.Discard)
+	loc, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		panic(err)
+	}
+	timesheet.DefaultTimeZone = loc
 
 	os.Exit(m.Run())
 }
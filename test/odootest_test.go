@@ -0,0 +1,283 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vshn/odootools/pkg/odoo/odootest"
+	"github.com/vshn/odootools/pkg/web"
+)
+
+func TestEndToEnd_LoginViewReportAndUpdatePayslip(t *testing.T) {
+	odooServer, server := startServer(t, odootest.DefaultFixtures())
+
+	// Login
+	cookies := login(t, server)
+
+	// View monthly report
+	res := serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022/03", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Attendance for Jane Doe")
+
+	// Update payslip
+	form := url.Values{"overtime": {"12:34:00"}}
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/employee/2/2022/03", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	payslip, found := odooServer.Record("hr.payslip", 2)
+	require.True(t, found)
+	assert.Equal(t, "12:34:00", payslip["x_overtime"])
+}
+
+func TestEndToEnd_CorrectAttendance(t *testing.T) {
+	odooServer, server := startServer(t, odootest.DefaultFixtures())
+
+	cookies := login(t, server)
+
+	// Show form
+	res := serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022/03/02", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Attendances of Jane Doe")
+
+	// Deleting the sign_out is rejected, since the day would become invalid
+	form := url.Values{"operation": {"delete"}, "id": {"6"}, "action": {"sign_out"}, "confirm": {"true"}}
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	assert.Contains(t, res.Body.String(), "the change would make the timesheet invalid")
+	_, found := odooServer.Record("hr.attendance", 6)
+	assert.True(t, found)
+
+	// Preview doesn't change the attendance
+	form = url.Values{"operation": {"update"}, "id": {"6"}, "action": {"sign_out"}, "time": {"17:00"}}
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Change sign_out to 17:00")
+	attendance, _ := odooServer.Record("hr.attendance", 6)
+	assert.Equal(t, "2022-03-02 15:00:00", attendance["name"])
+
+	// Confirm saves the attendance in UTC
+	form.Set("confirm", "true")
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "/report/2/2022/03", res.Header().Get("Location"))
+	attendance, _ = odooServer.Record("hr.attendance", 6)
+	assert.Equal(t, "2022-03-02 16:00:00", attendance["name"])
+}
+
+func TestEndToEnd_CorrectAttendanceOfOtherDay(t *testing.T) {
+	odooServer, server := startServer(t, odootest.DefaultFixtures())
+
+	cookies := login(t, server)
+
+	// The sign_in of 2022-03-01 can't be deleted with the form of 2022-03-02
+	form := url.Values{"operation": {"delete"}, "id": {"1"}, "action": {"sign_in"}, "confirm": {"true"}}
+	res := serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	assert.Contains(t, res.Body.String(), "sign_in with ID 1 doesn&#39;t belong to 2022-03-02")
+	_, found := odooServer.Record("hr.attendance", 1)
+	assert.True(t, found)
+}
+
+func TestEndToEnd_CorrectAttendanceOfSettledMonth(t *testing.T) {
+	fixtures := withAttendances(
+		attendanceRecord(7, "2022-02-15 07:00:00", "sign_in"),
+		attendanceRecord(8, "2022-02-15 15:00:00", "sign_out"),
+	)
+	// Jane is only an employee
+	fixtures.Models["res.groups"][0]["users"] = []interface{}{}
+	odooServer, server := startServer(t, fixtures)
+
+	cookies := login(t, server)
+
+	// February is settled by the payslip with overtime
+	form := url.Values{"operation": {"update"}, "id": {"8"}, "action": {"sign_out"}, "time": {"17:00"}, "confirm": {"true"}}
+	res := serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/02/15", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusForbidden, res.Code)
+	assert.Contains(t, res.Body.String(), "they have been settled with payslip")
+	attendance, _ := odooServer.Record("hr.attendance", 8)
+	assert.Equal(t, "2022-02-15 15:00:00", attendance["name"])
+
+	// March isn't settled yet
+	form = url.Values{"operation": {"update"}, "id": {"6"}, "action": {"sign_out"}, "time": {"17:00"}}
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Change sign_out to 17:00")
+}
+
+func TestEndToEnd_ApplySuggestedFix(t *testing.T) {
+	odooServer, server := startServer(t, withAttendances(
+		attendanceRecord(7, "2022-03-02 07:00:00", "sign_in"),
+	))
+
+	cookies := login(t, server)
+
+	// Suggestions are shown in the monthly and employee reports
+	res := serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022/03", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "duplicate sign_in detected for 2022-03-02 at 08:00:00")
+	assert.Contains(t, res.Body.String(), "Delete sign_in")
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/employees/2022/03", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Delete sign_in")
+
+	// Outdated suggestions are rejected
+	form := url.Values{"operation": {"fix"}, "kind": {"missing_sign_out"}, "finding": {"0"}, "fix": {"0"}, "confirm": {"true"}}
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	assert.Contains(t, res.Body.String(), "the suggested fix doesn&#39;t apply anymore")
+
+	// One click applies the fix
+	form.Set("kind", "duplicate_attendance")
+	res = serve(server, httptest.NewRequest(http.MethodPost, "/report/2/2022/03/02", strings.NewReader(form.Encode())), cookies)
+	require.Equal(t, http.StatusFound, res.Code)
+	assert.Len(t, odooServer.Records("hr.attendance"), 6)
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022/03", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.NotContains(t, res.Body.String(), "duplicate sign_in detected")
+}
+
+func TestEndToEnd_ShiftAcrossMidnight(t *testing.T) {
+	_, server := startServer(t, withAttendances(
+		attendanceRecord(7, "2022-03-04 21:00:00", "sign_in"),
+		attendanceRecord(8, "2022-03-05 01:00:00", "sign_out"),
+	))
+
+	cookies := login(t, server)
+
+	res := serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022/03", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Shift from 22:00 continues on next day")
+	assert.Contains(t, res.Body.String(), "Shift continued from previous day until 02:00")
+	assert.NotContains(t, res.Body.String(), "Your timesheet contains errors.")
+}
+
+func TestEndToEnd_ShowAllFindings(t *testing.T) {
+	_, server := startServer(t, withAttendances(
+		// overlaps with the morning shift of 2022-03-01
+		attendanceRecord(7, "2022-03-01 08:00:00", "sign_in"),
+		attendanceRecord(8, "2022-03-01 10:00:00", "sign_out"),
+		// long shift
+		attendanceRecord(9, "2022-03-04 05:00:00", "sign_in"),
+		attendanceRecord(10, "2022-03-04 18:30:00", "sign_out"),
+	))
+
+	cookies := login(t, server)
+
+	for _, path := range []string{"/report/2/2022/03", "/report/employees/2022/03"} {
+		res := serve(server, httptest.NewRequest(http.MethodGet, path, nil), cookies)
+		require.Equal(t, http.StatusOK, res.Code, path)
+		assert.Contains(t, res.Body.String(), "overlapping shifts detected for 2022-03-01 between 08:00:00 and 12:00:00", path)
+		assert.Contains(t, res.Body.String(), "Merge overlapping shifts", path)
+		assert.Contains(t, res.Body.String(), "shift from 06:00:00 until 19:30:00 on 2022-03-04 lasts 13h30m0s", path)
+	}
+}
+
+func TestEndToEnd_RangeReport(t *testing.T) {
+	_, server := startServer(t, odootest.DefaultFixtures())
+
+	cookies := login(t, server)
+
+	// The form redirects to the range report
+	form := url.Values{"from": {"2022-02-28"}, "to": {"2022-03-06"}, "rangeReport": {"true"}}
+	req := httptest.NewRequest(http.MethodPost, "/report", strings.NewReader(form.Encode()))
+	res := serve(server, req, cookies)
+	require.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "/report/2/range?from=2022-02-28&to=2022-03-06", res.Header().Get("Location"))
+
+	// Across the month boundary
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/2/range?from=2022-02-28&to=2022-03-06", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+	assert.Contains(t, body, "2022-02-28 – 2022-03-06, Europe/Zurich")
+	assert.Contains(t, body, "Week 9, 2022")
+	assert.Contains(t, body, "17:00:00", "total worked hours")
+	assert.Contains(t, body, "/report/2/range?from=2022-03-07&amp;to=2022-03-13", "next range link")
+
+	// Across the year boundary
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/2/range?from=2021-12-27&to=2022-01-09", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Week 52, 2021")
+	assert.Contains(t, res.Body.String(), "Week 1, 2022")
+
+	// Invalid range
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/2/range?from=2022-03-06&to=2022-02-28", nil), cookies)
+	require.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Body.String(), "the last day of the range (2022-02-28) cannot be before the first day (2022-03-06)")
+
+	// Range too long
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/2/range?from=2021-01-01&to=2022-03-06", nil), cookies)
+	require.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Body.String(), "cannot be longer than a year")
+}
+
+func TestEndToEnd_VacationReport(t *testing.T) {
+	_, server := startServer(t, odootest.DefaultFixtures())
+
+	cookies := login(t, server)
+
+	res := serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), `href="/report/2/2022/vacation"`)
+
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/2/2022/vacation", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+	assert.Contains(t, body, "Vacation balance 2022 for Jane Doe")
+	assert.Contains(t, body, "Legal Leaves 2022")
+	for _, days := range []string{"25.0d", "1.0d", "2.0d", "24.0d"} {
+		assert.Contains(t, body, days)
+	}
+
+	res = serve(server, httptest.NewRequest(http.MethodGet, "/report/employees/2022/vacation", nil), cookies)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), `href="/report/2/2022/vacation"`)
+	assert.Contains(t, res.Body.String(), "24.0d")
+}
+
+// startServer starts a fake Odoo with the given fixtures and returns it together with the web server using it.
+func startServer(t *testing.T, fixtures odootest.Fixtures) (*odootest.Server, *web.Server) {
+	odooServer := odootest.NewServer(fixtures)
+	t.Cleanup(odooServer.Close)
+	return odooServer, newServer(odooServer.URL)
+}
+
+// withAttendances returns the default fixtures with additional attendances of Jane Doe.
+func withAttendances(attendances ...odootest.Record) odootest.Fixtures {
+	fixtures := odootest.DefaultFixtures()
+	fixtures.Models["hr.attendance"] = append(fixtures.Models["hr.attendance"], attendances...)
+	return fixtures
+}
+
+// attendanceRecord returns an attendance of Jane Doe without reason at the given time in UTC.
+func attendanceRecord(id int, dateTime, action string) odootest.Record {
+	return odootest.Record{"id": id, "employee_id": []interface{}{2, "Jane Doe"}, "name": dateTime, "action": action, "action_desc": false}
+}
+
+// login signs in as Jane Doe and returns the session cookies.
+func login(t *testing.T, server *web.Server) []*http.Cookie {
+	form := url.Values{"login": {"jane"}, "password": {"secret"}}
+	res := serve(server, httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode())), nil)
+	require.Equal(t, http.StatusFound, res.Code)
+	cookies := res.Result().Cookies()
+	require.NotEmpty(t, cookies)
+	return cookies
+}
+
+func serve(server *web.Server, req *http.Request, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	if req.Method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, req)
+	return res
+}
@@ -2,10 +2,11 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/urfave/cli/v2"
-	"github.com/vshn/odootools/pkg/odoo"
+	"github.com/vshn/odootools/pkg/odoo/model"
 	"github.com/vshn/odootools/pkg/timesheet"
 	"github.com/vshn/odootools/pkg/web"
 )
@@ -18,16 +19,43 @@ func RunWebServer(cli *cli.Context) error {
 	}
 	timesheet.DefaultTimeZone = loc
 
-	client, err := odoo.NewClient(cli.String(newOdooURLFlag().Name), odoo.ClientOptions{UseDebugLogger: cli.Int(newLogLevelFlag().Name) >= 2})
+	if path := cli.String(newRulesFlag().Name); path != "" {
+		ruleSet, err := timesheet.LoadRuleSetFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot load rules: %w", err)
+		}
+		timesheet.DefaultRuleSet = ruleSet
+	}
+
+	client, err := newOdooClient(cli)
 	if err != nil {
 		return err
 	}
+	version, err := odooVersion(cli, client)
+	switch {
+	case err != nil && cli.String(newOdooVersionFlag().Name) != "":
+		return err
+	case err != nil:
+		// Odoo may not be reachable yet, the web server starts anyway.
+		log.Printf("Warning: %v. Falling back to the models of Odoo 8", err)
+	default:
+		log.Printf("Using models of Odoo %s", version)
+	}
 	server := web.NewServer(
 		client,
 		cli.String(newSecretKeyFlag().Name),
 		cli.String(newOdooDBFlag().Name),
 		versionInfo,
 	)
+	server.UseOdooVersion(version)
+
+	if cli.Bool(newCheckSchemaFlag().Name) {
+		querier, err := connectTechnicalUser(cli)
+		if err != nil {
+			return err
+		}
+		server.AddReadinessCheck("odoo schema", web.NewSchemaCheck(querier, model.Schema(version)))
+	}
 
 	addr := cli.String(newListenAddress().Name)
 
@@ -42,14 +70,16 @@ func newWebCommand() *cli.Command {
 		Name:   "web",
 		Usage:  "Starts the web server",
 		Action: RunWebServer,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			newOdooURLFlag(),
 			newOdooDBFlag(),
 			newSecretKeyFlag(),
 			newListenAddress(),
 			newDefaultTimezoneFlag(),
+			newRulesFlag(),
 			newTLSCertFlag(),
 			newTLSKeyFlag(),
-		},
+			newCheckSchemaFlag(),
+		}, newOdooTransportFlags()...),
 	}
 }